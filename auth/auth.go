@@ -4,8 +4,10 @@ package auth
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -25,6 +27,10 @@ import (
 // Signature format: {timestamp}{HTTP_METHOD}{URL_PATH}
 // Example: "1704067200000GET/v1/portfolio/positions"
 func SignRequest(req *http.Request, cfg *config.Config) error {
+	if !cfg.HasCredentials() {
+		return ErrNoCredentials
+	}
+
 	// Generate timestamp in milliseconds
 	// Doc: api/authentication.mdx - "Current Unix timestamp in milliseconds"
 	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
@@ -50,36 +56,42 @@ func SignRequest(req *http.Request, cfg *config.Config) error {
 	return nil
 }
 
+// ErrNoCredentials is returned when signing is attempted with a config that
+// has no API key or private key (see config.LoadPublic).
+var ErrNoCredentials = errors.New("no API credentials configured")
+
 // GenerateWSHeaders generates authentication headers for WebSocket connections.
 // WebSocket uses same auth as REST: X-PM-Access-Key, X-PM-Timestamp, X-PM-Signature
+// Without credentials, the headers are empty.
 func GenerateWSHeaders(cfg *config.Config) http.Header {
-	headers := make(http.Header)
-
-	// Generate timestamp in milliseconds
-	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
-
-	// Sign: {timestamp}GET{path}
-	message := timestamp + "GET" + "/v1/ws/private"
-	signature := ed25519.Sign(cfg.PrivateKey, []byte(message))
-	signatureB64 := base64.StdEncoding.EncodeToString(signature)
-
-	// WebSocket uses same headers as REST API
-	headers.Set("X-PM-Access-Key", cfg.APIKey)
-	headers.Set("X-PM-Timestamp", timestamp)
-	headers.Set("X-PM-Signature", signatureB64)
-
-	return headers
+	return generateWSHeaders(cfg, cfg.WSPrivateURL, cfg.APIPath("/ws/private"))
 }
 
 // GenerateWSMarketsHeaders generates authentication headers for the markets WebSocket.
 // WebSocket uses same auth as REST: X-PM-Access-Key, X-PM-Timestamp, X-PM-Signature
 func GenerateWSMarketsHeaders(cfg *config.Config) http.Header {
+	return generateWSHeaders(cfg, cfg.WSMarketsURL, cfg.APIPath("/ws/markets"))
+}
+
+// generateWSHeaders signs a WebSocket handshake for wsURL. The signed path is
+// taken from the URL itself so mount prefixes and API versions are included;
+// defaultPath is used only if the URL cannot be parsed.
+func generateWSHeaders(cfg *config.Config, wsURL, defaultPath string) http.Header {
 	headers := make(http.Header)
+	if !cfg.HasCredentials() {
+		return headers
+	}
 
+	path := defaultPath
+	if u, err := url.Parse(wsURL); err == nil && u.Path != "" {
+		path = u.Path
+	}
+
+	// Generate timestamp in milliseconds
 	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
 
 	// Sign: {timestamp}GET{path}
-	message := timestamp + "GET" + "/v1/ws/markets"
+	message := timestamp + "GET" + path
 	signature := ed25519.Sign(cfg.PrivateKey, []byte(message))
 	signatureB64 := base64.StdEncoding.EncodeToString(signature)
 
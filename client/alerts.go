@@ -0,0 +1,144 @@
+package client
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// LiteAlertReason describes why a LiteWatcher fired.
+type LiteAlertReason int
+
+const (
+	LiteAlertBidDepth    LiteAlertReason = iota + 1 // BidDepth crossed DepthThreshold
+	LiteAlertAskDepth                               // AskDepth crossed DepthThreshold
+	LiteAlertBestBidMove                            // BestBid moved by at least Ticks
+	LiteAlertBestAskMove                            // BestAsk moved by at least Ticks
+)
+
+// String returns a short label for the reason.
+func (r LiteAlertReason) String() string {
+	switch r {
+	case LiteAlertBidDepth:
+		return "bid_depth"
+	case LiteAlertAskDepth:
+		return "ask_depth"
+	case LiteAlertBestBidMove:
+		return "best_bid_move"
+	case LiteAlertBestAskMove:
+		return "best_ask_move"
+	}
+	return "unknown"
+}
+
+// LiteWatcher filters a market data lite stream down to meaningful changes,
+// firing OnAlert only when book depth crosses a threshold or the top of book
+// moves by a number of ticks. Use Observe as the OnMarketDataLite handler of
+// a SubscribeMarketDataLite consumer:
+//
+//	watcher := &client.LiteWatcher{TickSize: "0.01", Ticks: 2, OnAlert: notify}
+//	handlers := &client.Handlers{OnMarketDataLite: watcher.Observe}
+//
+// Doc: api-reference/websocket/markets.mdx - Market Data Lite Response
+type LiteWatcher struct {
+	// DepthThreshold fires when BidDepth or AskDepth crosses this value in
+	// either direction. Zero disables depth alerts.
+	DepthThreshold int
+
+	// TickSize is the market's price increment, e.g. "0.01".
+	TickSize string
+
+	// Ticks fires when BestBid or BestAsk has moved at least this many ticks
+	// from the price at the previous alert. Zero disables price alerts.
+	Ticks int
+
+	// OnAlert receives the previous and current update for the market.
+	OnAlert func(reason LiteAlertReason, prev, cur *models.MarketDataLiteUpdate)
+
+	mu      sync.Mutex
+	last    map[string]models.MarketDataLiteUpdate
+	anchors map[string]*liteAnchor
+}
+
+// liteAnchor holds the top-of-book prices at the last price alert.
+type liteAnchor struct {
+	bid, ask *big.Rat
+}
+
+// Observe processes one update, firing OnAlert for every threshold crossed.
+// The first update for a market only establishes the baseline.
+func (w *LiteWatcher) Observe(cur *models.MarketDataLiteUpdate) {
+	if cur == nil || w.OnAlert == nil {
+		return
+	}
+
+	w.mu.Lock()
+	if w.last == nil {
+		w.last = make(map[string]models.MarketDataLiteUpdate)
+		w.anchors = make(map[string]*liteAnchor)
+	}
+
+	prev, seen := w.last[cur.MarketSlug]
+	w.last[cur.MarketSlug] = *cur
+
+	anchor, ok := w.anchors[cur.MarketSlug]
+	if !ok {
+		anchor = &liteAnchor{}
+		w.anchors[cur.MarketSlug] = anchor
+	}
+
+	var reasons []LiteAlertReason
+	if seen && w.DepthThreshold > 0 {
+		if crossed(prev.BidDepth, cur.BidDepth, w.DepthThreshold) {
+			reasons = append(reasons, LiteAlertBidDepth)
+		}
+		if crossed(prev.AskDepth, cur.AskDepth, w.DepthThreshold) {
+			reasons = append(reasons, LiteAlertAskDepth)
+		}
+	}
+	if w.Ticks > 0 {
+		if moved(&anchor.bid, cur.BestBid, w.TickSize, w.Ticks) {
+			reasons = append(reasons, LiteAlertBestBidMove)
+		}
+		if moved(&anchor.ask, cur.BestAsk, w.TickSize, w.Ticks) {
+			reasons = append(reasons, LiteAlertBestAskMove)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, reason := range reasons {
+		w.OnAlert(reason, &prev, cur)
+	}
+}
+
+// crossed reports whether a value moved from one side of threshold to the other.
+func crossed(prev, cur, threshold int) bool {
+	return (prev < threshold) != (cur < threshold)
+}
+
+// moved reports whether price is at least ticks*tickSize away from *anchor,
+// resetting the anchor when it is. A nil anchor is initialized without firing.
+func moved(anchor **big.Rat, price *models.Amount, tickSize string, ticks int) bool {
+	px, err := price.Rat()
+	if err != nil {
+		return false
+	}
+	if *anchor == nil {
+		*anchor = px
+		return false
+	}
+
+	tick, err := models.ParseDecimal(tickSize)
+	if err != nil || tick.Sign() <= 0 {
+		return false
+	}
+	step := new(big.Rat).Mul(tick, big.NewRat(int64(ticks), 1))
+
+	diff := new(big.Rat).Sub(px, *anchor)
+	if diff.Abs(diff).Cmp(step) < 0 {
+		return false
+	}
+	*anchor = px
+	return true
+}
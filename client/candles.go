@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// Candle is an OHLC bar for one market over [StartTime, StartTime+Interval).
+// A bar with no trades carries the previous close forward as all four
+// prices, with zero Volume and Trades.
+type Candle struct {
+	MarketSlug string
+	StartTime  time.Time
+	Interval   time.Duration
+	Open       *big.Rat
+	High       *big.Rat
+	Low        *big.Rat
+	Close      *big.Rat
+	Volume     *big.Rat // Shares traded
+	Trades     int
+}
+
+// CandleBuilder aggregates the trade feed into OHLC candles per market.
+// Pass its Observe method as Handlers.OnTrade; completed candles are
+// delivered to OnCandle. A candle completes when a trade arrives after its
+// interval or, if Run is active, when its interval ends.
+//
+// Bars are aligned to multiples of Interval since the zero time and use the
+// trade's reported time, falling back to the local clock when it is missing.
+// Trades older than a market's open bar (e.g. replayed after a reconnect)
+// are ignored. A market's first candle starts with its first trade.
+type CandleBuilder struct {
+	Interval time.Duration
+	OnCandle func(Candle)
+
+	mu   sync.Mutex
+	bars map[string]*Candle // open bar per market
+}
+
+// NewCandleBuilder creates a builder that emits interval-long candles to
+// onCandle.
+func NewCandleBuilder(interval time.Duration, onCandle func(Candle)) (*CandleBuilder, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid candle interval %s: must be positive", interval)
+	}
+	return &CandleBuilder{
+		Interval: interval,
+		OnCandle: onCandle,
+		bars:     make(map[string]*Candle),
+	}, nil
+}
+
+// Observe adds a trade to its market's open candle, first completing any
+// candles whose interval has ended. Trades without a valid price are
+// skipped.
+func (b *CandleBuilder) Observe(t *models.TradeUpdate) {
+	px, err := t.Price.Rat()
+	if err != nil {
+		return
+	}
+	qty, err := t.Quantity.Rat()
+	if err != nil {
+		qty = new(big.Rat)
+	}
+	at, err := t.TradeTimeParsed()
+	if err != nil {
+		at = time.Now()
+	}
+
+	b.mu.Lock()
+	done := b.advance(t.MarketSlug, at)
+	bar := b.bars[t.MarketSlug]
+	switch {
+	case bar == nil:
+		bar = &Candle{
+			MarketSlug: t.MarketSlug,
+			StartTime:  at.Truncate(b.Interval),
+			Interval:   b.Interval,
+			Open:       px,
+			High:       px,
+			Low:        px,
+			Close:      px,
+			Volume:     new(big.Rat),
+		}
+		b.bars[t.MarketSlug] = bar
+	case at.Before(bar.StartTime):
+		b.mu.Unlock()
+		b.emit(done)
+		return
+	}
+	if bar.Trades == 0 {
+		bar.Open, bar.High, bar.Low = px, px, px
+	}
+	if px.Cmp(bar.High) > 0 {
+		bar.High = px
+	}
+	if px.Cmp(bar.Low) < 0 {
+		bar.Low = px
+	}
+	bar.Close = px
+	bar.Volume = new(big.Rat).Add(bar.Volume, qty)
+	bar.Trades++
+	b.mu.Unlock()
+
+	b.emit(done)
+}
+
+// Flush completes every open candle whose interval ended at or before now,
+// emitting carry-forward candles for intervals without trades.
+func (b *CandleBuilder) Flush(now time.Time) {
+	b.mu.Lock()
+	var done []Candle
+	for slug := range b.bars {
+		done = append(done, b.advance(slug, now)...)
+	}
+	b.mu.Unlock()
+
+	b.emit(done)
+}
+
+// Run calls Flush at every interval boundary until ctx is done, so candles
+// complete on time even when a market stops trading.
+func (b *CandleBuilder) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		next := now.Truncate(b.Interval).Add(b.Interval)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			b.Flush(next)
+		}
+	}
+}
+
+// advance completes slug's open bar and any empty bars after it, up to the
+// bar containing now. Callers must hold b.mu.
+func (b *CandleBuilder) advance(slug string, now time.Time) []Candle {
+	bar := b.bars[slug]
+	if bar == nil {
+		return nil
+	}
+	var done []Candle
+	for !now.Before(bar.StartTime.Add(b.Interval)) {
+		done = append(done, *bar)
+		bar = &Candle{
+			MarketSlug: slug,
+			StartTime:  bar.StartTime.Add(b.Interval),
+			Interval:   b.Interval,
+			Open:       bar.Close,
+			High:       bar.Close,
+			Low:        bar.Close,
+			Close:      bar.Close,
+			Volume:     new(big.Rat),
+		}
+	}
+	b.bars[slug] = bar
+	return done
+}
+
+// emit delivers completed candles outside the lock.
+func (b *CandleBuilder) emit(candles []Candle) {
+	if b.OnCandle == nil {
+		return
+	}
+	for _, c := range candles {
+		b.OnCandle(c)
+	}
+}
+
+// PriceHistoryIntervals maps the interval names GetPriceHistory accepts to
+// their bar length.
+var PriceHistoryIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// GetPriceHistory retrieves OHLC candles for a market between start and end,
+// one per interval (a key of PriceHistoryIntervals, e.g. "1h"). The candles
+// share the Candle type with CandleBuilder, so history can be charted and
+// then extended from the live trade feed.
+//
+// Note: a price history endpoint is not documented. This calls
+// GET /v1/markets/{slug}/price-history with interval, startTime, and endTime
+// (RFC 3339) parameters; a server without it fails with an *APIError.
+func (c *RestClient) GetPriceHistory(slug, interval string, start, end time.Time) ([]Candle, error) {
+	slug, err := validateSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	length, ok := PriceHistoryIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("invalid interval %q: expected one of 1m, 5m, 15m, 1h, 4h, 1d", interval)
+	}
+	if start.IsZero() || end.IsZero() {
+		return nil, fmt.Errorf("start and end times are required")
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("invalid time range: start %s is not before end %s",
+			start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	params := url.Values{}
+	params.Set("interval", interval)
+	params.Set("startTime", start.UTC().Format(time.RFC3339))
+	params.Set("endTime", end.UTC().Format(time.RFC3339))
+	path := "/markets/" + url.PathEscape(slug) + "/price-history?" + params.Encode()
+
+	respBody, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.GetPriceHistoryResponse
+	if err := c.decodeResponse(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	candles := make([]Candle, 0, len(result.Bars))
+	for i, bar := range result.Bars {
+		candle, err := historyCandle(slug, length, bar)
+		if err != nil {
+			return nil, fmt.Errorf("bar %d: %w", i, err)
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// historyCandle converts a price history bar to a Candle.
+func historyCandle(slug string, interval time.Duration, bar models.PriceHistoryBar) (Candle, error) {
+	startTime, err := models.ParseTimestamp(bar.StartTime)
+	if err != nil {
+		return Candle{}, fmt.Errorf("startTime: %w", err)
+	}
+	candle := Candle{
+		MarketSlug: slug,
+		StartTime:  startTime,
+		Interval:   interval,
+		Trades:     bar.Trades,
+	}
+	prices := []struct {
+		name string
+		dst  **big.Rat
+		src  *models.Amount
+	}{
+		{"open", &candle.Open, bar.Open},
+		{"high", &candle.High, bar.High},
+		{"low", &candle.Low, bar.Low},
+		{"close", &candle.Close, bar.Close},
+	}
+	for _, p := range prices {
+		if *p.dst, err = p.src.Rat(); err != nil {
+			return Candle{}, fmt.Errorf("%s: %w", p.name, err)
+		}
+	}
+	if candle.Volume, err = models.ParseDecimal(bar.Volume); err != nil {
+		return Candle{}, fmt.Errorf("volume: %w", err)
+	}
+	return candle, nil
+}
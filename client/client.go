@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/polymarket/retail-sample-client-go/config"
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// Client combines the REST and WebSocket clients for one set of credentials
+// and keeps state derived from the private stream, such as the latest
+// balance per currency and order states.
+type Client struct {
+	REST   *RestClient
+	WS     *WSClient
+	Orders *OrderCache // Populated once WS.SubscribeAllOrders (or SubscribeOrders) is active
+
+	// Positions is kept current by WS.SubscribePositions; seed it with
+	// Positions.Sync.
+	Positions *PositionCache
+
+	// OnCorrection, if set, receives each correction made by Reconcile.
+	OnCorrection func(Correction)
+
+	mu       sync.Mutex
+	balances map[string]models.Balance // keyed by currency
+	changed  chan struct{}             // closed and replaced on every balance change
+}
+
+// NewClient creates a combined client, passing opts to both underlying
+// clients. Call WS.Connect before subscribing.
+func NewClient(cfg *config.Config, opts ...ClientOption) *Client {
+	c := &Client{
+		REST:     NewRestClient(cfg, opts...),
+		WS:       NewWSClient(cfg, opts...),
+		balances: make(map[string]models.Balance),
+		changed:  make(chan struct{}),
+	}
+	c.Orders = newOrderCache(c.REST)
+	c.Positions = newPositionCache(c.REST)
+	c.WS.observe(c.observeBalances)
+	c.WS.observe(c.Orders.observe)
+	c.WS.observe(c.Positions.observe)
+	return c
+}
+
+// observeBalances updates the balance cache from balance stream messages.
+// Doc: api-reference/websocket/private.mdx - Account Balance Subscriptions
+func (c *Client) observeBalances(msg *models.WSMessage) {
+	var updated []models.Balance
+	if msg.AccountBalancesSnapshot != nil {
+		updated = append(updated, msg.AccountBalancesSnapshot.Balances...)
+	}
+	if u := msg.AccountBalancesUpdate; u != nil && u.BalanceChange != nil && u.BalanceChange.AfterBalance != nil {
+		updated = append(updated, *u.BalanceChange.AfterBalance)
+	}
+	if len(updated) == 0 {
+		return
+	}
+	c.storeBalances(updated)
+}
+
+// storeBalances records balances and wakes any waiters.
+func (c *Client) storeBalances(balances []models.Balance) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range balances {
+		c.balances[b.Currency] = b
+	}
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Balance returns the latest cached balance for currency, as seen on the
+// balance stream or fetched by WaitForBalance.
+func (c *Client) Balance(currency string) (models.Balance, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.balances[currency]
+	return b, ok
+}
+
+// WaitForBalance blocks until the balance for currency satisfies predicate,
+// returning that balance, or until ctx is done. Use it to wait for a deposit
+// or fill to be reflected without polling GetBalances.
+//
+// The balance stream must be subscribed (WS.SubscribeBalances) for updates
+// to arrive. If no balance has been seen yet, the cache is seeded once from
+// GetBalances, so a predicate that already holds returns immediately.
+// Doc: api-reference/websocket/private.mdx - Account Balance Subscriptions
+func (c *Client) WaitForBalance(ctx context.Context, currency string, predicate func(models.Balance) bool) (models.Balance, error) {
+	c.mu.Lock()
+	seeded := len(c.balances) > 0
+	c.mu.Unlock()
+
+	if !seeded {
+		resp, err := c.REST.GetBalances()
+		if err != nil {
+			return models.Balance{}, fmt.Errorf("failed to seed balances: %w", err)
+		}
+		c.mu.Lock()
+		// The stream may have delivered fresher balances during the fetch.
+		if len(c.balances) == 0 {
+			for _, b := range resp.Balances {
+				c.balances[b.Currency] = b
+			}
+		}
+		c.mu.Unlock()
+	}
+
+	for {
+		c.mu.Lock()
+		b, ok := c.balances[currency]
+		changed := c.changed
+		c.mu.Unlock()
+
+		if ok && predicate(b) {
+			return b, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return models.Balance{}, ctx.Err()
+		case <-changed:
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// Subscription declares one subscription for ConnectWith.
+type Subscription struct {
+	Category    models.SubscriptionCategory
+	MarketSlugs []string // Empty subscribes to all markets where the stream allows it
+
+	// Market data only; see SubscribeMarketData.
+	Depth     int
+	Debounced bool
+}
+
+// ConnectWith connects and subscribes to subs, waiting until every
+// subscription is acknowledged. It returns the request IDs in the order of
+// subs. The subscriptions are registered like those made with the
+// Subscribe* methods, so they are replayed after a reconnect.
+//
+// It is all or nothing: if any subscription fails to send, is rejected, or
+// times out, the others are unsubscribed and the combined errors returned.
+// The connection stays up; Close it or subscribe again.
+// Doc: api-reference/websocket/overview.mdx - Connection, Subscribing
+func (c *WSClient) ConnectWith(subs ...Subscription) ([]string, error) {
+	if err := c.Connect(); err != nil {
+		return nil, err
+	}
+
+	requestIDs := make([]string, 0, len(subs))
+	var errs []error
+	for i, s := range subs {
+		id, err := c.subscribeTo(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %d (%s): %w", i, s.Category, err))
+			break
+		}
+		requestIDs = append(requestIDs, id)
+	}
+	// Rejected and expired subscriptions leave the registry on their own;
+	// only the live ones need rolling back.
+	var live []string
+	for _, id := range requestIDs {
+		if err := c.AwaitSubscription(id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		live = append(live, id)
+	}
+	if len(errs) == 0 {
+		return requestIDs, nil
+	}
+
+	for _, id := range live {
+		if err := c.Unsubscribe(id); err != nil {
+			log.Printf("[WS] Failed to roll back %s: %v", id, err)
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// subscribeTo sends s through the matching Subscribe* method.
+func (c *WSClient) subscribeTo(s Subscription) (string, error) {
+	switch s.Category {
+	case models.SubscriptionCategoryOrder:
+		return c.SubscribeOrders(s.MarketSlugs)
+	case models.SubscriptionCategoryPosition:
+		return c.SubscribePositions(s.MarketSlugs)
+	case models.SubscriptionCategoryAccountBalance:
+		return c.SubscribeBalances()
+	case models.SubscriptionCategoryMarketData:
+		return c.SubscribeMarketData(s.MarketSlugs, s.Depth, s.Debounced)
+	case models.SubscriptionCategoryMarketDataLite:
+		return c.SubscribeMarketDataLite(s.MarketSlugs)
+	case models.SubscriptionCategoryTrade:
+		return c.SubscribeTrades(s.MarketSlugs)
+	}
+	return "", fmt.Errorf("cannot subscribe to %s", s.Category)
+}
+
+// HeldSubscriptions returns the subscriptions for monitoring markets the
+// user holds: orders, positions, and full-depth market data for slugs. It
+// returns nil for no slugs, since an empty slug list would subscribe to
+// every market.
+func HeldSubscriptions(slugs []string) []Subscription {
+	if len(slugs) == 0 {
+		return nil
+	}
+	return []Subscription{
+		{Category: models.SubscriptionCategoryOrder, MarketSlugs: slugs},
+		{Category: models.SubscriptionCategoryPosition, MarketSlugs: slugs},
+		{Category: models.SubscriptionCategoryMarketData, MarketSlugs: slugs},
+	}
+}
+
+// ConnectHeld connects and subscribes to every market the user currently
+// holds, so a restarted bot resumes monitoring its existing book with one
+// call. Held markets are read from all pages of GetPositions; see
+// models.HeldMarketSlugs. It returns the held slugs and the request IDs of
+// the subscriptions, which are all or nothing as with ConnectWith. With no
+// positions held, it only connects.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions
+func (c *Client) ConnectHeld(ctx context.Context) (slugs, requestIDs []string, err error) {
+	positions, err := c.REST.getAllPositionsContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	slugs = models.HeldMarketSlugs(positions)
+	requestIDs, err = c.WS.ConnectWith(HeldSubscriptions(slugs)...)
+	if err != nil {
+		return slugs, nil, err
+	}
+	return slugs, requestIDs, nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/polymarket/retail-sample-client-go/config"
+)
+
+// withCredentials returns a copy of cfg using apiKey and privKey, after
+// checking that both are usable.
+func withCredentials(cfg *config.Config, apiKey string, privKey ed25519.PrivateKey) (*config.Config, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key: expected %d bytes, got %d", ed25519.PrivateKeySize, len(privKey))
+	}
+	updated := *cfg
+	updated.APIKey = apiKey
+	updated.PrivateKey = privKey
+	return &updated, nil
+}
+
+// currentConfig returns the configuration in effect for the next request.
+func (c *RestClient) currentConfig() *config.Config {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.config
+}
+
+// UpdateCredentials switches the client to a new API key and private key,
+// e.g. during key rotation. Requests already in flight finish with the
+// credentials they were signed with; every request started afterwards,
+// including retries, is signed with the new key.
+// Doc: api/authentication.mdx - API key configuration
+func (c *RestClient) UpdateCredentials(apiKey string, privKey ed25519.PrivateKey) error {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	updated, err := withCredentials(c.config, apiKey, privKey)
+	if err != nil {
+		return err
+	}
+	c.config = updated
+	return nil
+}
+
+// currentConfig returns the configuration used for the next dial.
+func (c *WSClient) currentConfig() *config.Config {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.config
+}
+
+// UpdateCredentials switches the client to a new API key and private key.
+// Credentials are only checked during the WebSocket handshake, so each
+// connected stream is replaced with one signed by the new key (the old
+// connection stays up until the new one is established) and its
+// subscriptions are replayed. Streams that are down or reconnecting pick up
+// the new key on their next dial. If a stream cannot connect with the new
+// key, its old connection is kept and the error is returned; the new key
+// stays in effect for later dials.
+// Doc: api/authentication.mdx - API key configuration
+func (c *WSClient) UpdateCredentials(apiKey string, privKey ed25519.PrivateKey) error {
+	c.configMu.Lock()
+	updated, err := withCredentials(c.config, apiKey, privKey)
+	if err != nil {
+		c.configMu.Unlock()
+		return err
+	}
+	c.config = updated
+	c.configMu.Unlock()
+
+	c.mu.Lock()
+	var streams []bool
+	if c.status(true) == StreamConnected {
+		streams = append(streams, true)
+	}
+	if c.status(false) == StreamConnected {
+		streams = append(streams, false)
+	}
+	c.mu.Unlock()
+
+	for _, private := range streams {
+		if err := c.replaceConnection(private, "credentials rotated"); err != nil {
+			return fmt.Errorf("failed to reconnect %s WebSocket with new credentials: %w", streamName(private), err)
+		}
+	}
+	return nil
+}
+
+// UpdateCredentials rotates the credentials of both the REST and WebSocket
+// clients. See RestClient.UpdateCredentials and WSClient.UpdateCredentials.
+func (c *Client) UpdateCredentials(apiKey string, privKey ed25519.PrivateKey) error {
+	if err := c.REST.UpdateCredentials(apiKey, privKey); err != nil {
+		return err
+	}
+	return c.WS.UpdateCredentials(apiKey, privKey)
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/polymarket/retail-sample-client-go/auth"
+	"github.com/polymarket/retail-sample-client-go/config"
+)
+
+// DiagnosticCheck is the outcome of one preflight check.
+type DiagnosticCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// DiagnosticsReport collects the preflight checks run by RunDiagnostics.
+type DiagnosticsReport struct {
+	Checks []DiagnosticCheck
+}
+
+// OK reports whether every check passed.
+func (r *DiagnosticsReport) OK() bool {
+	for _, c := range r.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders one line per check.
+func (r *DiagnosticsReport) String() string {
+	var b strings.Builder
+	for _, c := range r.Checks {
+		status := "PASS"
+		if !c.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", status, c.Name, c.Detail)
+	}
+	return b.String()
+}
+
+func (r *DiagnosticsReport) add(name string, err error, detail string) {
+	if err != nil {
+		r.Checks = append(r.Checks, DiagnosticCheck{Name: name, Detail: err.Error()})
+		return
+	}
+	r.Checks = append(r.Checks, DiagnosticCheck{Name: name, Passed: true, Detail: detail})
+}
+
+// RFC 8032 section 7.1, TEST 1: a known Ed25519 key pair and the signature of
+// the empty message.
+const (
+	rfc8032Seed      = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	rfc8032PublicKey = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	rfc8032Signature = "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+)
+
+// RunDiagnostics checks that cfg is usable before trading: the signing
+// implementation against a known vector, the configured key pair, the URLs,
+// the local clock against the server's, and an authenticated request. Every
+// check runs even if earlier ones fail, so the report shows all problems at
+// once.
+// Doc: api/authentication.mdx - Ed25519 signature generation, Timestamp Validation
+func RunDiagnostics(cfg *config.Config) *DiagnosticsReport {
+	report := &DiagnosticsReport{}
+
+	report.add("signing vector", checkSigningVector(), "Ed25519 matches RFC 8032 test vector")
+
+	keyErr := checkKeyPair(cfg.PrivateKey)
+	report.add("private key", keyErr, "public key "+cfg.PublicKeyBase64())
+
+	report.add("base URL", checkURL(cfg.BaseURL, "https", "http"), cfg.BaseURL)
+	report.add("private WebSocket URL", checkURL(cfg.WSPrivateURL, "wss", "ws"), cfg.WSPrivateURL)
+	report.add("markets WebSocket URL", checkURL(cfg.WSMarketsURL, "wss", "ws"), cfg.WSMarketsURL)
+
+	if keyErr != nil {
+		report.add("server", errors.New("skipped: private key is invalid"), "")
+		return report
+	}
+
+	skew, status, err := pingServer(cfg)
+	if err != nil {
+		report.add("server", err, "")
+		return report
+	}
+	report.add("server", nil, fmt.Sprintf("reachable (HTTP %d)", status))
+
+	if skew == nil {
+		report.add("clock", errors.New("server did not send a Date header"), "")
+	} else {
+		report.add("clock", auth.ValidateTimestamp(time.Now().Add(-*skew).UnixMilli()),
+			fmt.Sprintf("local clock within %s of server", skew.Abs().Round(time.Second)))
+	}
+
+	var authErr error
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		authErr = fmt.Errorf("request rejected with HTTP %d; check the API key, private key, and clock", status)
+	}
+	report.add("authentication", authErr, "signed request accepted")
+
+	return report
+}
+
+// checkSigningVector signs the RFC 8032 test message and compares the result.
+func checkSigningVector() error {
+	seed, _ := hex.DecodeString(rfc8032Seed)
+	wantPub, _ := hex.DecodeString(rfc8032PublicKey)
+	wantSig, _ := hex.DecodeString(rfc8032Signature)
+
+	key := ed25519.NewKeyFromSeed(seed)
+	if !bytes.Equal(key.Public().(ed25519.PublicKey), wantPub) {
+		return errors.New("public key derivation does not match RFC 8032")
+	}
+	if !bytes.Equal(ed25519.Sign(key, nil), wantSig) {
+		return errors.New("signature does not match RFC 8032")
+	}
+	return nil
+}
+
+// checkKeyPair verifies the private key is well formed and that a signature
+// made with it verifies against its derived public key.
+func checkKeyPair(key ed25519.PrivateKey) error {
+	if len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid private key length %d", len(key))
+	}
+	pub := key.Public().(ed25519.PublicKey)
+	if !bytes.Equal(ed25519.NewKeyFromSeed(key.Seed()), key) {
+		return fmt.Errorf("private key does not match its embedded public key %s",
+			base64.StdEncoding.EncodeToString(pub))
+	}
+	msg := []byte("diagnostics")
+	if !ed25519.Verify(pub, msg, ed25519.Sign(key, msg)) {
+		return errors.New("signature does not verify with the derived public key")
+	}
+	return nil
+}
+
+// checkURL verifies raw is an absolute URL with one of schemes.
+func checkURL(raw string, schemes ...string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", raw)
+	}
+	for _, s := range schemes {
+		if u.Scheme == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q has scheme %q, expected %s", raw, u.Scheme, strings.Join(schemes, " or "))
+}
+
+// pingServer sends a signed GET /v1/account/balances and returns the clock
+// skew from the Date header (nil if absent) and the HTTP status.
+// Doc: api-reference/account/overview.mdx - GET /v1/account/balances
+func pingServer(cfg *config.Config) (*time.Duration, int, error) {
+	rest := NewRestClient(cfg)
+	req, err := http.NewRequest(http.MethodGet, cfg.BaseURL+cfg.APIPath("/account/balances"), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := auth.SignRequest(req, cfg); err != nil {
+		return nil, 0, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := rest.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return nil, resp.StatusCode, nil
+	}
+	skew := time.Since(date)
+	return &skew, resp.StatusCode, nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// ErrConnectionClosed is returned by Consume when a WebSocket connection
+// drops while consuming and is not being reconnected.
+var ErrConnectionClosed = errors.New("websocket connection closed")
+
+// Handlers holds typed callbacks for WebSocket messages. Nil callbacks are
+// skipped, so consumers only set the ones they care about. Messages are
+// routed by payload type rather than by subscription, so overlapping
+// subscriptions (e.g. full and lite data for one market) each reach their
+// own callback.
+// Doc: api-reference/websocket/private.mdx, api-reference/websocket/markets.mdx
+type Handlers struct {
+	// OnError receives subscription errors reported by the server.
+	OnError func(requestID, err string)
+
+	OnOrderSnapshot   func(*models.OrderSnapshot)
+	OnOrderUpdate     func(*models.OrderUpdate)
+	OnPositionUpdate  func(*models.PositionUpdate)
+	OnBalanceSnapshot func(*models.BalanceSnapshot)
+	OnBalanceUpdate   func(*models.BalanceUpdate)
+	OnMarketData      func(*models.MarketDataUpdate)
+	OnMarketDataLite  func(*models.MarketDataLiteUpdate)
+	OnTrade           func(*models.TradeUpdate)
+
+	// OnSnapshotComplete is called after OnOrderSnapshot for the message
+	// that ends an order snapshot (EOF set). Snapshots may span several
+	// messages; treat updates after this call as live.
+	OnSnapshotComplete func(requestID string)
+
+	// OnMessage, if set, receives every message before the typed callbacks,
+	// including ones no typed callback matches.
+	OnMessage func(*models.WSMessage)
+}
+
+// Dispatch routes a message to the matching typed callbacks.
+func (h *Handlers) Dispatch(msg *models.WSMessage) {
+	if msg == nil {
+		return
+	}
+	if h.OnMessage != nil {
+		h.OnMessage(msg)
+	}
+
+	if msg.Error != "" {
+		if h.OnError != nil {
+			h.OnError(msg.RequestID, msg.Error)
+		}
+		return
+	}
+
+	if msg.OrderSubscriptionSnapshot != nil {
+		if h.OnOrderSnapshot != nil {
+			h.OnOrderSnapshot(msg.OrderSubscriptionSnapshot)
+		}
+		if msg.OrderSubscriptionSnapshot.EOF && h.OnSnapshotComplete != nil {
+			h.OnSnapshotComplete(msg.RequestID)
+		}
+	}
+	if msg.OrderSubscriptionUpdate != nil && h.OnOrderUpdate != nil {
+		h.OnOrderUpdate(msg.OrderSubscriptionUpdate)
+	}
+	if msg.PositionSubscription != nil && h.OnPositionUpdate != nil {
+		h.OnPositionUpdate(msg.PositionSubscription)
+	}
+	if msg.AccountBalancesSnapshot != nil && h.OnBalanceSnapshot != nil {
+		h.OnBalanceSnapshot(msg.AccountBalancesSnapshot)
+	}
+	if msg.AccountBalancesUpdate != nil && h.OnBalanceUpdate != nil {
+		h.OnBalanceUpdate(msg.AccountBalancesUpdate)
+	}
+	if msg.MarketData != nil && h.OnMarketData != nil {
+		h.OnMarketData(msg.MarketData)
+	}
+	if msg.MarketDataLite != nil && h.OnMarketDataLite != nil {
+		h.OnMarketDataLite(msg.MarketDataLite)
+	}
+	if msg.Trade != nil && h.OnTrade != nil {
+		h.OnTrade(msg.Trade)
+	}
+}
+
+// Consume reads messages and dispatches them to h until ctx is done, the
+// client is closed, or a connection drops for good. It returns ctx.Err() on
+// cancellation, nil after Close, and ErrConnectionClosed on a dropped
+// connection that is not being reconnected (after dispatching any messages
+// already buffered). Drops handled by auto-reconnect do not end Consume.
+func (c *WSClient) Consume(ctx context.Context, h *Handlers) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.done:
+			return nil
+		case <-c.disconnected:
+			for {
+				select {
+				case msg := <-c.messages:
+					h.Dispatch(msg)
+				default:
+					return ErrConnectionClosed
+				}
+			}
+		case msg := <-c.messages:
+			h.Dispatch(msg)
+		}
+	}
+}
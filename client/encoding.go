@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent on every REST request. Setting it explicitly turns
+// off net/http's transparent gzip handling, so readBody decodes the response
+// itself according to Content-Encoding.
+const acceptEncoding = "gzip, deflate"
+
+// readBody reads resp's body, decompressing it according to its
+// Content-Encoding. Identity and unknown-but-empty encodings are read as is.
+func readBody(resp *http.Response) ([]byte, error) {
+	var body io.Reader = resp.Body
+
+	for _, enc := range contentEncodings(resp.Header) {
+		switch enc {
+		case "", "identity":
+		case "gzip", "x-gzip":
+			zr, err := gzip.NewReader(body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gzip body: %w", err)
+			}
+			defer zr.Close()
+			body = zr
+		case "deflate":
+			// RFC 9110 deflate is zlib-wrapped, but some servers send a raw
+			// deflate stream; fall back to that when the zlib header is
+			// missing.
+			br := bufio.NewReader(body)
+			if hdr, err := br.Peek(2); err == nil && isZlibHeader(hdr) {
+				zr, err := zlib.NewReader(br)
+				if err != nil {
+					return nil, fmt.Errorf("invalid deflate body: %w", err)
+				}
+				defer zr.Close()
+				body = zr
+			} else {
+				fr := flate.NewReader(br)
+				defer fr.Close()
+				body = fr
+			}
+		default:
+			return nil, fmt.Errorf("unsupported content encoding %q", enc)
+		}
+	}
+
+	return io.ReadAll(body)
+}
+
+// contentEncodings returns the codings in resp's Content-Encoding header in
+// the order they must be removed (the reverse of the order applied).
+func contentEncodings(h http.Header) []string {
+	var codings []string
+	for _, v := range h.Values("Content-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			codings = append(codings, strings.ToLower(strings.TrimSpace(enc)))
+		}
+	}
+	for i, j := 0, len(codings)-1; i < j; i, j = i+1, j-1 {
+		codings[i], codings[j] = codings[j], codings[i]
+	}
+	return codings
+}
+
+// isZlibHeader reports whether b starts with a valid zlib header: deflate
+// method with a header checksum divisible by 31.
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestReadBodyContentEncoding(t *testing.T) {
+	const payload = `{"markets":[{"slug":"test-market"}]}`
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		w.Write([]byte(payload))
+		w.Close()
+		return buf.Bytes()
+	}
+	gzipped := compress(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })
+	zlibbed := compress(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })
+	deflated := compress(func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	})
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		wantErr  bool
+	}{
+		{"identity", "", []byte(payload), false},
+		{"gzip", "gzip", gzipped, false},
+		{"x-gzip", "x-gzip", gzipped, false},
+		{"deflate zlib", "deflate", zlibbed, false},
+		{"deflate raw", "deflate", deflated, false},
+		{"unsupported", "br", []byte(payload), true},
+		{"corrupt gzip", "gzip", []byte(payload), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(tt.body))}
+			if tt.encoding != "" {
+				resp.Header.Set("Content-Encoding", tt.encoding)
+			}
+			got, err := readBody(resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readBody error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != payload {
+				t.Errorf("readBody = %q, want %q", got, payload)
+			}
+		})
+	}
+}
+
+func TestRequestDecodesGzipResponse(t *testing.T) {
+	var gotAccept string
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"ok":true}`))
+		zw.Close()
+	}))
+
+	body, err := c.doRequest(http.MethodGet, "/markets", nil)
+	if err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("body = %q, want decompressed JSON", body)
+	}
+	if gotAccept != acceptEncoding {
+		t.Errorf("Accept-Encoding = %q, want %q", gotAccept, acceptEncoding)
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// APIError is returned for non-2xx HTTP responses. Use errors.As to inspect
+// the status and any fields decoded from the JSON error envelope.
+type APIError struct {
+	StatusCode int
+	Body       string // Raw response body
+	RequestID  string // Server request ID from the response headers, if any; quote it in support tickets
+
+	// Populated when the body is a JSON error envelope; empty otherwise.
+	Code    string
+	Message string
+	Details []json.RawMessage
+}
+
+// newAPIError builds an APIError, decoding the error envelope if present.
+func newAPIError(status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Body: string(body)}
+	if parsed, ok := models.ParseAPIErrorBody(body); ok {
+		e.Code = string(parsed.Code)
+		e.Message = parsed.Message
+		e.Details = parsed.Details
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error %d: %s (request ID: %s)", e.StatusCode, e.Body, e.RequestID)
+	}
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body)
+}
+
+// DuplicateOrderError is returned by CreateOrder when the server rejects the
+// order as a duplicate of one it already accepted. ExistingOrderID is set
+// when the server identifies the original order; look it up with GetOrder.
+//
+// Note: CreateOrderRequest has no idempotency key or client order ID, so the
+// client cannot correlate a resubmission with its original by itself, and
+// CreateOrder is never retried automatically. Detection depends entirely on
+// the server reporting the duplicate.
+type DuplicateOrderError struct {
+	ExistingOrderID string
+	Err             *APIError
+}
+
+func (e *DuplicateOrderError) Error() string {
+	if e.ExistingOrderID != "" {
+		return fmt.Sprintf("duplicate order (existing order %s): %v", e.ExistingOrderID, e.Err)
+	}
+	return fmt.Sprintf("duplicate order: %v", e.Err)
+}
+
+func (e *DuplicateOrderError) Unwrap() error {
+	return e.Err
+}
+
+// asDuplicateOrder converts an API error that reports a duplicate order into
+// a *DuplicateOrderError. Other errors are returned unchanged.
+func asDuplicateOrder(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.isDuplicate() {
+		return err
+	}
+	return &DuplicateOrderError{
+		ExistingOrderID: apiErr.detailString("orderId", "order_id", "existingOrderId", "existing_order_id"),
+		Err:             apiErr,
+	}
+}
+
+// isDuplicate reports whether the error indicates a duplicate submission:
+// HTTP 409 Conflict, or a code or message mentioning a duplicate.
+func (e *APIError) isDuplicate() bool {
+	if e.StatusCode == http.StatusConflict {
+		return true
+	}
+	for _, s := range []string{e.Code, e.Message} {
+		if strings.Contains(strings.ToLower(s), "duplicate") {
+			return true
+		}
+	}
+	return false
+}
+
+// detailString returns the first string value found under any of keys in
+// the error details.
+func (e *APIError) detailString(keys ...string) string {
+	for _, raw := range e.Details {
+		var detail map[string]interface{}
+		if err := json.Unmarshal(raw, &detail); err != nil {
+			continue
+		}
+		for _, key := range keys {
+			if s, ok := detail[key].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// OrderRejectedError is returned by CreateOrder when the response reports
+// the order as immediately rejected, which happens with synchronous
+// execution. The response is returned alongside the error.
+// Doc: api-reference/orders/overview.mdx - Synchronous Execution
+type OrderRejectedError struct {
+	OrderID string
+	Reason  string // Execution.OrderRejectReason
+	Text    string // Execution.Text
+}
+
+func (e *OrderRejectedError) Error() string {
+	msg := fmt.Sprintf("order %s rejected", e.OrderID)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Text != "" {
+		msg += " (" + e.Text + ")"
+	}
+	return msg
+}
+
+// rejection returns an *OrderRejectedError if any execution in resp is a
+// rejection, or nil. A rejection for a risk limit is returned as a
+// *RiskLimitError wrapping the *OrderRejectedError.
+func rejection(resp *models.CreateOrderResponse) error {
+	for _, exec := range resp.Executions {
+		if exec.Type == models.ExecutionTypeRejected {
+			if riskErr := riskRejection(resp.ID, &exec, false); riskErr != nil {
+				return riskErr
+			}
+			return &OrderRejectedError{
+				OrderID: resp.ID,
+				Reason:  exec.OrderRejectReason,
+				Text:    exec.Text,
+			}
+		}
+	}
+	return nil
+}
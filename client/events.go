@@ -0,0 +1,191 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"syscall"
+
+	"github.com/gorilla/websocket"
+)
+
+// Stream names used in ConnectionEvent.
+const (
+	StreamPrivate = "private"
+	StreamMarkets = "markets"
+)
+
+// ConnectionEventType distinguishes connection lifecycle events.
+type ConnectionEventType int
+
+const (
+	ConnectionEventConnected ConnectionEventType = iota
+	ConnectionEventDisconnected
+
+	// ConnectionEventReconnectFailed is terminal: the stream exhausted
+	// WSMaxReconnectAttempts and will not be retried. Err holds the last
+	// dial error.
+	ConnectionEventReconnectFailed
+
+	// ConnectionEventSubscriptionFailed reports a subscription the server
+	// rejected when it was replayed after a reconnect. It is terminal for
+	// that subscription, which is no longer replayed until
+	// WSClient.Resubscribe. Err is the *SubscriptionError.
+	ConnectionEventSubscriptionFailed
+)
+
+// String returns a lowercase label for the event type.
+func (t ConnectionEventType) String() string {
+	switch t {
+	case ConnectionEventConnected:
+		return "connected"
+	case ConnectionEventReconnectFailed:
+		return "reconnect failed"
+	case ConnectionEventSubscriptionFailed:
+		return "subscription failed"
+	}
+	return "disconnected"
+}
+
+// CloseReason classifies why a WebSocket connection ended.
+type CloseReason int
+
+const (
+	CloseReasonUnknown         CloseReason = iota // Close code not recognized
+	CloseReasonClientClosed                       // Closed by Close
+	CloseReasonNormal                             // 1000: server ended the session cleanly
+	CloseReasonGoingAway                          // 1001: server shutting down or restarting
+	CloseReasonAbnormal                           // 1006 or other error without a close frame
+	CloseReasonPolicyViolation                    // 1008: the server rejected the client's behavior
+	CloseReasonServerError                        // 1011: unexpected server condition
+	CloseReasonServiceRestart                     // 1012: server restarting
+	CloseReasonTryAgainLater                      // 1013: server overloaded
+	CloseReasonAuthFailure                        // 4001/4003: credentials rejected or revoked
+	CloseReasonNetworkError                       // Connection reset, EOF, or read timeout
+)
+
+// Application close codes for authentication failures.
+// Note: application close codes are not documented; these follow the common
+// 4000 + HTTP status convention.
+const (
+	closeCodeUnauthorized = 4001
+	closeCodeForbidden    = 4003
+)
+
+// String returns a short description of the reason.
+func (r CloseReason) String() string {
+	switch r {
+	case CloseReasonClientClosed:
+		return "closed by client"
+	case CloseReasonNormal:
+		return "normal closure"
+	case CloseReasonGoingAway:
+		return "server going away"
+	case CloseReasonAbnormal:
+		return "connection lost"
+	case CloseReasonPolicyViolation:
+		return "policy violation"
+	case CloseReasonServerError:
+		return "server error"
+	case CloseReasonServiceRestart:
+		return "service restart"
+	case CloseReasonTryAgainLater:
+		return "try again later"
+	case CloseReasonAuthFailure:
+		return "authentication failure"
+	case CloseReasonNetworkError:
+		return "network error"
+	}
+	return "unknown"
+}
+
+// Retryable reports whether reconnecting is likely to succeed. Closures
+// caused by the client itself, by policy, or by rejected credentials will
+// recur on reconnect and should be surfaced to the operator instead.
+func (r CloseReason) Retryable() bool {
+	switch r {
+	case CloseReasonGoingAway, CloseReasonAbnormal, CloseReasonServerError,
+		CloseReasonServiceRestart, CloseReasonTryAgainLater, CloseReasonNetworkError,
+		CloseReasonUnknown:
+		return true
+	}
+	return false
+}
+
+// ConnectionEvent reports a WebSocket connection coming up or going down.
+// For Disconnected events, Reason classifies the close; Code and Text carry
+// the close frame's code and reason text when one was received.
+type ConnectionEvent struct {
+	Type   ConnectionEventType
+	Stream string // StreamPrivate or StreamMarkets
+	Reason CloseReason
+	Code   int
+	Text   string
+	Err    error
+}
+
+// Events returns a channel of connection lifecycle events. Events are
+// dropped if the channel is not drained.
+func (c *WSClient) Events() <-chan ConnectionEvent {
+	return c.events
+}
+
+// emit queues an event without blocking the read loop.
+func (c *WSClient) emit(event ConnectionEvent) {
+	select {
+	case c.events <- event:
+	default:
+		log.Printf("[WS] Event channel full, dropping %s event for %s", event.Type, event.Stream)
+	}
+}
+
+// classifyClose extracts the close code and text from a read error and maps
+// the code to a CloseReason. Errors without a close frame are network errors
+// when the transport failed, and abnormal otherwise.
+func classifyClose(err error) (CloseReason, int, string) {
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		if err == nil {
+			return CloseReasonUnknown, 0, ""
+		}
+		if isNetworkError(err) {
+			return CloseReasonNetworkError, websocket.CloseAbnormalClosure, ""
+		}
+		return CloseReasonAbnormal, websocket.CloseAbnormalClosure, ""
+	}
+
+	reason := CloseReasonUnknown
+	switch closeErr.Code {
+	case websocket.CloseNormalClosure:
+		reason = CloseReasonNormal
+	case websocket.CloseGoingAway:
+		reason = CloseReasonGoingAway
+	case websocket.CloseAbnormalClosure:
+		reason = CloseReasonAbnormal
+	case websocket.ClosePolicyViolation:
+		reason = CloseReasonPolicyViolation
+	case websocket.CloseInternalServerErr:
+		reason = CloseReasonServerError
+	case websocket.CloseServiceRestart:
+		reason = CloseReasonServiceRestart
+	case websocket.CloseTryAgainLater:
+		reason = CloseReasonTryAgainLater
+	case closeCodeUnauthorized, closeCodeForbidden:
+		reason = CloseReasonAuthFailure
+	}
+	return reason, closeErr.Code, closeErr.Text
+}
+
+// isNetworkError reports whether err is a transport failure below the
+// WebSocket protocol: a reset or broken connection, an unexpected EOF, or a
+// timeout. These usually clear up on a fresh connection.
+func isNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// TradeFilter selects trades from the public trade feed.
+type TradeFilter func(*models.TradeUpdate) bool
+
+// FilterTrades wraps handler so it only receives trades accepted by every
+// filter. Use the result as Handlers.OnTrade.
+func FilterTrades(handler func(*models.TradeUpdate), filters ...TradeFilter) func(*models.TradeUpdate) {
+	return func(t *models.TradeUpdate) {
+		for _, f := range filters {
+			if !f(t) {
+				return
+			}
+		}
+		handler(t)
+	}
+}
+
+// TakerSide accepts trades whose aggressor (taker) was on the given side.
+func TakerSide(side models.OrderSide) TradeFilter {
+	return func(t *models.TradeUpdate) bool {
+		return t.Taker != nil && t.Taker.Side == side
+	}
+}
+
+// TakerIntent accepts trades whose aggressor (taker) had the given intent.
+func TakerIntent(intent models.OrderIntent) TradeFilter {
+	return func(t *models.TradeUpdate) bool {
+		return t.Taker != nil && t.Taker.Intent == intent
+	}
+}
+
+// defaultOwnTradeWindow is how long a fill remains eligible for matching.
+const defaultOwnTradeWindow = 5 * time.Second
+
+// OwnTradeMatcher isolates the account's own trades in the public feed.
+//
+// The trade feed carries no account or trade identifiers, so the server
+// cannot filter it per user. Instead, fills from the private order stream
+// are recorded and each public trade is matched to a recent fill in the same
+// market with the same price and quantity. Matching is best-effort: two
+// identical trades in the same window are indistinguishable. For exact
+// accounting, use the order stream's executions directly.
+// Doc: api-reference/websocket/private.mdx - Order Update Response
+// Doc: api-reference/websocket/markets.mdx - Trade Response
+type OwnTradeMatcher struct {
+	// Window is how long after a fill a matching public trade is accepted.
+	// Defaults to 5s.
+	Window time.Duration
+
+	mu    sync.Mutex
+	fills []ownFill
+}
+
+// ownFill is a fill awaiting its public trade.
+type ownFill struct {
+	exec     *models.Execution
+	received time.Time
+}
+
+// RecordExecution records fills from an order update. Use it as (or call it
+// from) Handlers.OnOrderUpdate.
+func (m *OwnTradeMatcher) RecordExecution(u *models.OrderUpdate) {
+	if u == nil || u.Execution == nil || u.Execution.Order == nil {
+		return
+	}
+	switch u.Execution.Type {
+	case models.ExecutionTypeFill, models.ExecutionTypePartialFill:
+	default:
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(time.Now())
+	m.fills = append(m.fills, ownFill{exec: u.Execution, received: time.Now()})
+}
+
+// Match returns the recorded fill corresponding to t, consuming it so it is
+// not matched twice.
+func (m *OwnTradeMatcher) Match(t *models.TradeUpdate) (*models.Execution, bool) {
+	price, err := t.Price.Rat()
+	if err != nil {
+		return nil, false
+	}
+	qty, err := t.Quantity.Rat()
+	if err != nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(time.Now())
+
+	for i, f := range m.fills {
+		if f.exec.Order.MarketSlug != t.MarketSlug {
+			continue
+		}
+		fillPx, err := f.exec.LastPx.Rat()
+		if err != nil || fillPx.Cmp(price) != 0 {
+			continue
+		}
+		fillQty, err := models.ParseDecimal(f.exec.LastShares)
+		if err != nil || fillQty.Cmp(qty) != 0 {
+			continue
+		}
+		m.fills = append(m.fills[:i], m.fills[i+1:]...)
+		return f.exec, true
+	}
+	return nil, false
+}
+
+// OwnTrades accepts only trades matched to the account's fills.
+func (m *OwnTradeMatcher) OwnTrades() TradeFilter {
+	return func(t *models.TradeUpdate) bool {
+		_, ok := m.Match(t)
+		return ok
+	}
+}
+
+// OwnTakerTrades accepts only the account's trades where it was the aggressor.
+func (m *OwnTradeMatcher) OwnTakerTrades() TradeFilter {
+	return func(t *models.TradeUpdate) bool {
+		exec, ok := m.Match(t)
+		return ok && exec.Aggressor
+	}
+}
+
+// OwnMakerTrades accepts only the account's trades where it provided liquidity.
+func (m *OwnTradeMatcher) OwnMakerTrades() TradeFilter {
+	return func(t *models.TradeUpdate) bool {
+		exec, ok := m.Match(t)
+		return ok && !exec.Aggressor
+	}
+}
+
+// prune drops fills older than the match window. Callers must hold m.mu.
+func (m *OwnTradeMatcher) prune(now time.Time) {
+	window := m.Window
+	if window <= 0 {
+		window = defaultOwnTradeWindow
+	}
+	kept := m.fills[:0]
+	for _, f := range m.fills {
+		if now.Sub(f.received) <= window {
+			kept = append(kept, f)
+		}
+	}
+	m.fills = kept
+}
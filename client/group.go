@@ -0,0 +1,136 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// DefaultMaxSlugsPerSubscription is the largest number of market slugs sent
+// in one subscribe message by SubscribeGroup.
+// Note: the server's per-subscription limit is not documented; this value is
+// conservative.
+const DefaultMaxSlugsPerSubscription = 100
+
+// SubscriptionGroup is a set of subscriptions of one category created
+// together by SubscribeGroup, one per chunk of market slugs.
+type SubscriptionGroup struct {
+	ID          string
+	Category    models.SubscriptionCategory
+	MarketSlugs []string
+	RequestIDs  []string // One per chunk, in slug order
+}
+
+// SubscribeGroup subscribes to category for many markets at once. The slugs
+// are split into chunks of at most DefaultMaxSlugsPerSubscription (see
+// WithMaxSlugsPerSubscription), each sent as a single subscribe message, and
+// the resulting subscriptions are tracked together under the returned
+// group's ID.
+//
+// Market data groups use the full book without debouncing; use
+// SubscribeMarketData directly for other settings. If any chunk fails to
+// send, the chunks already sent are unsubscribed and the error is returned.
+// Doc: api-reference/websocket/overview.mdx - Subscribing
+func (c *WSClient) SubscribeGroup(category models.SubscriptionCategory, marketSlugs []string) (*SubscriptionGroup, error) {
+	switch category {
+	case models.SubscriptionCategoryUnknown:
+		return nil, fmt.Errorf("cannot subscribe to %s", category)
+	case models.SubscriptionCategoryAccountBalance:
+		return nil, fmt.Errorf("%s does not take market slugs; use SubscribeBalances", category)
+	}
+
+	group := &SubscriptionGroup{
+		ID:          c.nextRequestID("group"),
+		Category:    category,
+		MarketSlugs: marketSlugs,
+	}
+
+	for i, chunk := range chunkSlugs(marketSlugs, c.maxSlugs) {
+		req := &models.WSSubscription{
+			RequestID:        fmt.Sprintf("%s.%d", group.ID, i),
+			SubscriptionType: category.RequestType(),
+			MarketSlugs:      chunk,
+		}
+		if err := c.subscribe(req, category.IsPrivate()); err != nil {
+			for _, id := range group.RequestIDs {
+				if uerr := c.Unsubscribe(id); uerr != nil {
+					log.Printf("[WS] Failed to roll back %s: %v", id, uerr)
+				}
+			}
+			return nil, fmt.Errorf("failed to subscribe chunk %d of group %s: %w", i, group.ID, err)
+		}
+		group.RequestIDs = append(group.RequestIDs, req.RequestID)
+	}
+
+	c.mu.Lock()
+	c.groups[group.ID] = group
+	c.mu.Unlock()
+
+	log.Printf("[WS] Subscribed group %s to %s (%d markets in %d subscriptions)",
+		group.ID, category, len(marketSlugs), len(group.RequestIDs))
+	return group, nil
+}
+
+// AwaitGroup waits for every subscription in the group to settle, returning
+// the combined errors of any that were rejected or timed out.
+func (c *WSClient) AwaitGroup(groupID string) error {
+	c.mu.Lock()
+	group, ok := c.groups[groupID]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown subscription group %q", groupID)
+	}
+
+	var errs []error
+	for _, id := range group.RequestIDs {
+		if err := c.AwaitSubscription(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UnsubscribeGroup cancels every subscription in the group. Subscriptions
+// the server already dropped are skipped; other failures are combined into
+// the returned error.
+// Doc: api-reference/websocket/overview.mdx - Unsubscribing
+func (c *WSClient) UnsubscribeGroup(groupID string) error {
+	c.mu.Lock()
+	group, ok := c.groups[groupID]
+	delete(c.groups, groupID)
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown subscription group %q", groupID)
+	}
+
+	var errs []error
+	for _, id := range group.RequestIDs {
+		c.mu.Lock()
+		_, live := c.subscriptions[id]
+		c.mu.Unlock()
+		if !live {
+			continue
+		}
+		if err := c.Unsubscribe(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// chunkSlugs splits slugs into consecutive chunks of at most size. An empty
+// list yields a single empty chunk, which subscribes to all markets where
+// the stream allows it.
+func chunkSlugs(slugs []string, size int) [][]string {
+	if len(slugs) == 0 {
+		return [][]string{nil}
+	}
+	var chunks [][]string
+	for start := 0; start < len(slugs); start += size {
+		end := min(start+size, len(slugs))
+		chunks = append(chunks, slugs[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,28 @@
+package client
+
+import "net/http"
+
+// ResponseInfo describes one REST response, as passed to WithResponseHook.
+type ResponseInfo struct {
+	Method     string
+	Path       string // Request path, without query
+	StatusCode int
+	Header     http.Header
+	RequestID  string // Server request ID, if the response carries one
+}
+
+// requestIDHeaders are the headers checked, in order, for the server's
+// request ID.
+// Note: the API does not document a request ID header; these are the common
+// names set by gateways and load balancers.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Cf-Id", "Cf-Ray"}
+
+// requestIDFromHeader returns the first request ID header present in h.
+func requestIDFromHeader(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
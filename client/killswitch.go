@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// killSwitchPollInterval is how often KillSwitch checks open orders over
+// REST, in case the order stream is not subscribed.
+const killSwitchPollInterval = time.Second
+
+// KillSwitch cancels every open order, or only those in slugs when given,
+// and waits until each canceled order is confirmed no longer open. It
+// returns the IDs of the canceled orders.
+//
+// Confirmation comes from the order stream when WS.SubscribeOrders is active,
+// and otherwise from polling GetOpenOrders. If ctx ends first, the orders
+// still open are reported in the error.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders/open/cancel
+func (c *Client) KillSwitch(ctx context.Context, slugs []string) ([]string, error) {
+	resp, err := c.REST.CancelAllOpenOrders(slugs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel open orders: %w", err)
+	}
+
+	pending := make(map[string]bool, len(resp.CanceledOrderIDs))
+	for _, id := range resp.CanceledOrderIDs {
+		pending[id] = true
+	}
+
+	ticker := time.NewTicker(killSwitchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		c.Orders.mu.Lock()
+		for id := range pending {
+			if o, ok := c.Orders.orders[id]; ok && o.State.IsTerminal() {
+				delete(pending, id)
+			}
+		}
+		changed := c.Orders.changed
+		c.Orders.mu.Unlock()
+
+		if len(pending) == 0 {
+			return resp.CanceledOrderIDs, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			ids := make([]string, 0, len(pending))
+			for id := range pending {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			return resp.CanceledOrderIDs, fmt.Errorf("%w: %d order(s) not confirmed canceled: %s",
+				ctx.Err(), len(ids), strings.Join(ids, ", "))
+		case <-changed:
+		case <-ticker.C:
+			open, err := c.REST.GetOpenOrders(slugs)
+			if err != nil {
+				continue
+			}
+			stillOpen := make(map[string]bool, len(open.Orders))
+			for _, o := range open.Orders {
+				stillOpen[o.ID] = true
+			}
+			for id := range pending {
+				if !stillOpen[id] {
+					delete(pending, id)
+				}
+			}
+		}
+	}
+}
+
+// CancelMarketOrders cancels every open order in one market and returns the
+// IDs of the canceled orders. With wait, it also waits, bounded by ctx, until
+// each one is confirmed no longer open, as KillSwitch does.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders/open/cancel
+func (c *Client) CancelMarketOrders(ctx context.Context, slug string, wait bool) ([]string, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("market slug is required")
+	}
+	if wait {
+		return c.KillSwitch(ctx, []string{slug})
+	}
+	resp, err := c.REST.CancelAllOpenOrders([]string{slug})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel open orders in %s: %w", slug, err)
+	}
+	return resp.CanceledOrderIDs, nil
+}
+
+// Shutdown stops the client gracefully. With cancelAll, it first runs
+// KillSwitch across all markets, bounded by ctx; the WebSocket connections
+// are closed either way. The kill switch error, if any, is returned.
+func (c *Client) Shutdown(ctx context.Context, cancelAll bool) error {
+	var killErr error
+	if cancelAll {
+		_, killErr = c.KillSwitch(ctx, nil)
+	}
+	if err := c.WS.Close(); err != nil && killErr == nil {
+		return err
+	}
+	return killErr
+}
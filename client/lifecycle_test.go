@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/polymarket/retail-sample-client-go/config"
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// fakeExchange serves the REST endpoints an order's lifecycle touches and
+// both WebSocket streams. Order subscriptions are answered with an empty
+// snapshot; the test pushes everything else on the private stream with
+// send.
+type fakeExchange struct {
+	srv *httptest.Server
+
+	mu      sync.Mutex
+	private *websocket.Conn
+	placed  []models.CreateOrderRequest
+	ready   chan struct{} // closed once the private stream is connected
+}
+
+func newFakeExchange(t *testing.T) *fakeExchange {
+	t.Helper()
+	x := &fakeExchange{ready: make(chan struct{})}
+	upgrader := websocket.Upgrader{}
+	x.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := r.URL.Path; {
+		case strings.HasSuffix(path, "/ws/private"):
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			x.mu.Lock()
+			x.private = conn
+			x.mu.Unlock()
+			close(x.ready)
+			x.answerSubscriptions(t, conn)
+		case strings.HasSuffix(path, "/ws/markets"):
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/orders"):
+			var req models.CreateOrderRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			x.mu.Lock()
+			x.placed = append(x.placed, req)
+			x.mu.Unlock()
+			w.Write([]byte(`{"id":"order-1"}`))
+		case strings.HasSuffix(path, "/portfolio/positions"):
+			w.Write([]byte(`{"positions":{},"eof":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(x.srv.Close)
+	return x
+}
+
+// answerSubscriptions reads subscribe requests from the private stream and
+// completes each order subscription with an empty snapshot.
+func (x *fakeExchange) answerSubscriptions(t *testing.T, conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req models.WSSubscribeRequest
+		if err := json.Unmarshal(data, &req); err != nil || req.Subscribe == nil {
+			continue
+		}
+		if req.Subscribe.SubscriptionType == models.SubscriptionTypeOrder {
+			x.send(t, &models.WSMessage{
+				RequestID:                 req.Subscribe.RequestID,
+				OrderSubscriptionSnapshot: &models.OrderSnapshot{Orders: []models.Order{}, EOF: true},
+			})
+		}
+	}
+}
+
+// send writes msg to the private stream.
+func (x *fakeExchange) send(t *testing.T, msg *models.WSMessage) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if err := x.private.WriteJSON(msg); err != nil {
+		t.Errorf("send: %v", err)
+	}
+}
+
+// config returns credentials for x's REST and WebSocket endpoints.
+func (x *fakeExchange) config(t *testing.T) *config.Config {
+	t.Helper()
+	_, pk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ws := "ws" + strings.TrimPrefix(x.srv.URL, "http")
+	return &config.Config{
+		BaseURL:      x.srv.URL,
+		WSPrivateURL: ws + "/ws/private",
+		WSMarketsURL: ws + "/ws/markets",
+		APIKey:       "test-key",
+		PrivateKey:   pk,
+	}
+}
+
+// execution builds an order update for one execution of order-1, a buy of
+// 10. shares and px describe the fill, if the execution traded.
+func execution(execType models.ExecutionType, state models.OrderState, cum, leaves float64, shares, px string) *models.WSMessage {
+	e := &models.Execution{
+		ID:   "exec-" + string(state),
+		Type: execType,
+		Order: &models.Order{
+			ID: "order-1", MarketSlug: "test-market", Quantity: 10,
+			CumQuantity: cum, LeavesQuantity: leaves, State: state,
+		},
+	}
+	if shares != "" {
+		e.LastShares = shares
+		e.LastPx = &models.Amount{Value: px, Currency: "USD"}
+	}
+	return &models.WSMessage{OrderSubscriptionUpdate: &models.OrderUpdate{Execution: e}}
+}
+
+// eventually polls cond until it holds or five seconds pass.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	x := newFakeExchange(t)
+	c := NewClient(x.config(t))
+	if err := c.WS.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.WS.Close()
+	<-x.ready
+
+	ordersID, err := c.WS.SubscribeAllOrders()
+	if err != nil {
+		t.Fatalf("SubscribeAllOrders: %v", err)
+	}
+	if err := c.WS.AwaitSubscription(ordersID); err != nil {
+		t.Fatalf("AwaitSubscription: %v", err)
+	}
+	<-c.Orders.Synced()
+	if _, err := c.WS.SubscribePositions(nil); err != nil {
+		t.Fatalf("SubscribePositions: %v", err)
+	}
+	if err := c.Positions.Sync(context.Background()); err != nil {
+		t.Fatalf("Positions.Sync: %v", err)
+	}
+
+	price := &models.Amount{Value: "0.60", Currency: "USD"}
+	resp, err := c.REST.CreateOrder(models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 10))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if resp.ID != "order-1" {
+		t.Fatalf("CreateOrder ID = %q, want order-1", resp.ID)
+	}
+
+	steps := []struct {
+		name      string
+		order     *models.WSMessage
+		position  *models.WSMessage
+		wantState models.OrderState
+		wantOpen  int
+		wantNet   string
+	}{
+		{
+			name:      "pending new",
+			order:     execution("", models.OrderStatePendingNew, 0, 10, "", ""),
+			wantState: models.OrderStatePendingNew,
+			wantOpen:  1,
+		},
+		{
+			name:  "partial fill",
+			order: execution(models.ExecutionTypePartialFill, models.OrderStatePartiallyFilled, 4, 6, "4", "0.50"),
+			position: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{QtyBought: "0", QtySold: "0"},
+				&models.UserPosition{QtyBought: "4", QtySold: "0"}),
+			wantState: models.OrderStatePartiallyFilled,
+			wantOpen:  1,
+			wantNet:   "4",
+		},
+		{
+			name:  "fill",
+			order: execution(models.ExecutionTypeFill, models.OrderStateFilled, 10, 0, "6", "0.60"),
+			position: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{QtyBought: "4", QtySold: "0"},
+				&models.UserPosition{QtyBought: "10", QtySold: "0"}),
+			wantState: models.OrderStateFilled,
+			wantOpen:  0,
+			wantNet:   "10",
+		},
+	}
+	for _, step := range steps {
+		x.send(t, step.order)
+		if step.position != nil {
+			x.send(t, step.position)
+		}
+		eventually(t, step.name, func() bool {
+			o, ok := c.Orders.Get("order-1")
+			if !ok || o.State != step.wantState {
+				return false
+			}
+			if step.wantNet == "" {
+				return true
+			}
+			p, ok := c.Positions.Get("test-market")
+			return ok && p.NetPosition == step.wantNet
+		})
+		if open := c.Orders.Open(); len(open) != step.wantOpen {
+			t.Errorf("%s: %d open orders, want %d", step.name, len(open), step.wantOpen)
+		}
+	}
+
+	o, _ := c.Orders.Get("order-1")
+	if o.CumQuantity != 10 || o.LeavesQuantity != 0 {
+		t.Errorf("final order cum %v leaves %v, want 10 and 0", o.CumQuantity, o.LeavesQuantity)
+	}
+	avg, err := c.Orders.AverageFillPrice("order-1")
+	if err != nil {
+		t.Fatalf("AverageFillPrice: %v", err)
+	}
+	if got := avg.FloatString(2); got != "0.56" {
+		t.Errorf("AverageFillPrice = %s, want 0.56", got)
+	}
+	if all := c.Positions.All(); len(all) != 1 {
+		t.Errorf("Positions.All = %v, want only test-market", all)
+	}
+	x.mu.Lock()
+	placed := len(x.placed)
+	x.mu.Unlock()
+	if placed != 1 {
+		t.Errorf("exchange received %d orders, want 1", placed)
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// MarketCache holds market details fetched by slug, so per-market order
+// constraints (tick size, minimum size) are looked up once rather than
+// before every order. Entries are kept until Invalidate is called.
+// Doc: api-reference/market/overview.mdx - GET /v1/market/slug/{slug}
+type MarketCache struct {
+	rest *RestClient
+
+	mu      sync.Mutex
+	markets map[string]*models.Market
+}
+
+// NewMarketCache creates an empty cache that fetches through rest.
+func NewMarketCache(rest *RestClient) *MarketCache {
+	return &MarketCache{
+		rest:    rest,
+		markets: make(map[string]*models.Market),
+	}
+}
+
+// Market returns the market for slug, fetching it on first use.
+func (c *MarketCache) Market(slug string) (*models.Market, error) {
+	c.mu.Lock()
+	m, ok := c.markets[slug]
+	c.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+
+	m, err := c.rest.GetMarketBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", slug, err)
+	}
+
+	c.mu.Lock()
+	c.markets[slug] = m
+	c.mu.Unlock()
+	return m, nil
+}
+
+// Invalidate drops the cached entry for slug, e.g. after an order is rejected
+// for a price increment the cache did not know about.
+func (c *MarketCache) Invalidate(slug string) {
+	c.mu.Lock()
+	delete(c.markets, slug)
+	c.mu.Unlock()
+}
+
+// ConformOrder rounds req's price to its market's tick size and checks the
+// minimum order size, using cached market details. See Market.ConformOrder.
+func (c *MarketCache) ConformOrder(req *models.CreateOrderRequest) error {
+	if req == nil {
+		return fmt.Errorf("order request is nil")
+	}
+	m, err := c.Market(req.MarketSlug)
+	if err != nil {
+		return err
+	}
+	return m.ConformOrder(req)
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// marketsPageSize is the page size used when paging through markets.
+const marketsPageSize = 100
+
+// GetMarketsByEvent returns every market belonging to eventSlug, e.g. all
+// outcomes of a multi-outcome event, paging with offset until a short page.
+// Doc: api-reference/market/overview.mdx - GET /v1/markets
+//
+// Note: the eventSlug filter is not in the documented filter list. Markets
+// that report a different EventSlug are dropped client-side in case the
+// server ignores the filter.
+func (c *RestClient) GetMarketsByEvent(eventSlug string) ([]models.Market, error) {
+	var markets []models.Market
+	for offset := 0; ; offset += marketsPageSize {
+		resp, err := c.QueryMarkets(MarketsQuery{
+			Limit:     marketsPageSize,
+			Offset:    offset,
+			EventSlug: eventSlug,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Markets {
+			if m.EventSlug == "" || m.EventSlug == eventSlug {
+				markets = append(markets, m)
+			}
+		}
+		if len(resp.Markets) < marketsPageSize {
+			return markets, nil
+		}
+	}
+}
+
+// QueryMarketQuotes retrieves one page of markets matching q, decoding only
+// the fields of models.MarketQuote. Use it for market-scanning workloads
+// that need prices but not descriptions or metadata. Unless q.Fields is
+// set, the server is asked for models.MarketQuoteFields.
+// Doc: api-reference/market/overview.mdx - GET /v1/markets
+func (c *RestClient) QueryMarketQuotes(q MarketsQuery) ([]models.MarketQuote, error) {
+	if len(q.Fields) == 0 {
+		q.Fields = models.MarketQuoteFields
+	}
+
+	respBody, err := c.doRequest("GET", marketsPath(q), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.GetMarketQuotesResponse
+	if err := c.decodeResponse(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Markets, nil
+}
+
+// GetRelatedMarkets returns the sibling markets of slug: the other outcome
+// markets of the same event (see Market.EventSlug), excluding slug itself.
+// Use them to hedge or to check that a multi-outcome event's prices are
+// consistent.
+//
+// A market without an EventSlug is a standalone binary market and has no
+// siblings; its NO outcome is not a separate market but the short side of
+// the same one, traded with ORDER_INTENT_BUY_SHORT and
+// ORDER_INTENT_SELL_SHORT, so an empty result is returned.
+// Doc: api-reference/market/overview.mdx - GET /v1/market/slug/{slug}
+func (c *RestClient) GetRelatedMarkets(slug string) ([]models.Market, error) {
+	market, err := c.GetMarketBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", slug, err)
+	}
+	if market.EventSlug == "" {
+		return nil, nil
+	}
+
+	markets, err := c.GetMarketsByEvent(market.EventSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets for event %s: %w", market.EventSlug, err)
+	}
+	siblings := make([]models.Market, 0, len(markets))
+	for _, m := range markets {
+		if m.Slug != market.Slug {
+			siblings = append(siblings, m)
+		}
+	}
+	return siblings, nil
+}
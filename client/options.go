@@ -0,0 +1,159 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption customizes a client built by NewRestClient, NewWSClient, or
+// NewClient. Options that do not apply to a given client are ignored, so the
+// same list can be passed to all three.
+type ClientOption func(*clientOptions)
+
+// clientOptions holds every option; zero values mean "use the default".
+type clientOptions struct {
+	// REST
+	httpClient     *http.Client
+	requestTimeout time.Duration
+	maxRetries     *int
+	retryBackoff   time.Duration
+	marketCheck    *bool
+	responseHook   func(ResponseInfo)
+	concurrency    int
+	validateSchema bool
+	strictDecode   bool
+
+	previewRounding bool
+
+	// WebSocket
+	subscribeTimeout time.Duration
+	writeTimeout     time.Duration
+	autoReconnect    *bool
+	messageBuffer    int
+	maxSlugs         int
+	maxLifetime      time.Duration
+	maxReconnects    *int
+	connectAttempts  int
+}
+
+func applyOptions(opts []ClientOption) *clientOptions {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithHTTPClient replaces the REST client's HTTP client. The caller is then
+// responsible for its transport, proxy, TLS, and timeout settings.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = hc }
+}
+
+// WithRequestTimeout sets the overall timeout of each REST attempt
+// (default: 30s). Ignored when WithHTTPClient is used.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) { o.requestTimeout = d }
+}
+
+// WithMaxRetries sets how many times idempotent requests are retried after
+// the first attempt (default: 3). Zero disables retries.
+func WithMaxRetries(n int) ClientOption {
+	return func(o *clientOptions) { o.maxRetries = &n }
+}
+
+// WithRetryBackoff sets the delay before the first retry; it doubles on each
+// subsequent retry (default: 500ms).
+func WithRetryBackoff(d time.Duration) ClientOption {
+	return func(o *clientOptions) { o.retryBackoff = d }
+}
+
+// WithResponseHook registers fn to receive the status and headers of every
+// REST response, including each retry attempt and error responses. Use it
+// to log the server's request ID for support tickets or to track rate-limit
+// headers. fn runs on the calling goroutine and must not block.
+func WithResponseHook(fn func(ResponseInfo)) ClientOption {
+	return func(o *clientOptions) { o.responseHook = fn }
+}
+
+// WithConcurrency limits how many requests a fan-out operation such as
+// GetAccountSummary runs at once (default: 4).
+func WithConcurrency(n int) ClientOption {
+	return func(o *clientOptions) { o.concurrency = n }
+}
+
+// WithSchemaValidation checks request bodies against their schema before
+// sending (default: disabled), so structural mistakes such as a missing
+// required field or an out-of-range enum fail locally with a
+// *models.SchemaError naming each offending field. Bodies that do not
+// implement models.SchemaValidator are sent unchecked.
+func WithSchemaValidation(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.validateSchema = enabled }
+}
+
+// WithStrictDecoding rejects REST responses and WebSocket messages that
+// carry fields the models do not define (default: disabled). Enable it in
+// tests or staging to surface new server fields as errors; leave it off in
+// production, where unknown fields are ignored. Strictly rejected
+// WebSocket messages are logged and dropped.
+func WithStrictDecoding(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.strictDecode = enabled }
+}
+
+// WithPreviewRounding makes PreviewOrder round a limit price to the
+// market's tick size before sending it, so the preview matches what the
+// server will do with the order. Tick sizes are looked up once per market
+// and cached (default: disabled).
+func WithPreviewRounding(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.previewRounding = enabled }
+}
+
+// WithMarketCheck enables or disables CreateOrder's pre-submission check
+// that the market is open for trading (default: disabled). The check costs
+// a market lookup per market, cached for a minute.
+func WithMarketCheck(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.marketCheck = &enabled }
+}
+
+// WithSubscribeTimeout overrides Config.WSSubscribeTimeout.
+func WithSubscribeTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) { o.subscribeTimeout = d }
+}
+
+// WithWriteTimeout overrides Config.WSWriteTimeout.
+func WithWriteTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) { o.writeTimeout = d }
+}
+
+// WithAutoReconnect overrides Config.WSAutoReconnect.
+func WithAutoReconnect(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.autoReconnect = &enabled }
+}
+
+// WithMessageBuffer sets the capacity of the Messages channel (default: 100).
+// Messages arriving while it is full are dropped.
+func WithMessageBuffer(n int) ClientOption {
+	return func(o *clientOptions) { o.messageBuffer = n }
+}
+
+// WithMaxSlugsPerSubscription sets the chunk size used by SubscribeGroup
+// (default: DefaultMaxSlugsPerSubscription).
+func WithMaxSlugsPerSubscription(n int) ClientOption {
+	return func(o *clientOptions) { o.maxSlugs = n }
+}
+
+// WithMaxConnectionLifetime overrides Config.WSMaxConnectionLifetime.
+func WithMaxConnectionLifetime(d time.Duration) ClientOption {
+	return func(o *clientOptions) { o.maxLifetime = d }
+}
+
+// WithMaxReconnectAttempts overrides Config.WSMaxReconnectAttempts; 0 means
+// unlimited.
+func WithMaxReconnectAttempts(n int) ClientOption {
+	return func(o *clientOptions) { o.maxReconnects = &n }
+}
+
+// WithConnectAttempts overrides Config.WSConnectAttempts.
+func WithConnectAttempts(n int) ClientOption {
+	return func(o *clientOptions) { o.connectAttempts = n }
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// OrderCache holds the latest known state of each order seen on the private
+// order stream. Orders whose quantities fail Order.Validate are refreshed
+// from REST, since drift usually means an update was missed.
+//
+// An order snapshot may span several messages; the cache collects them and
+// applies the snapshot only once a message with EOF set arrives. Orders that
+// received a live update while the snapshot was in flight keep the update,
+// so the switch from snapshot to live mode neither regresses nor drops
+// orders.
+//
+// The cache only sees orders in the markets the order stream is subscribed
+// to; subscribe with WSClient.SubscribeAllOrders to keep it complete.
+//
+// Fill executions are accumulated per order into a volume-weighted average
+// fill price; see AverageFillPrice.
+//
+// A live good-till-date order whose GoodTillTime has passed is reported as
+// ORDER_STATE_EXPIRED with no leaves by Get and Open, without waiting for
+// the server's expiry execution; the stored copy is left as received, so
+// the server's next update still applies.
+//
+// Orders rejected by the server's risk checks, including those held in
+// ORDER_STATE_PENDING_RISK first, are recorded; see RiskRejection.
+// Doc: api-reference/websocket/private.mdx - Order Subscriptions
+type OrderCache struct {
+	rest *RestClient
+
+	mu         sync.Mutex
+	orders     map[string]models.Order
+	refreshing map[string]bool
+	changed    chan struct{}             // closed and replaced on every change
+	snapshots  map[string]*orderSnapshot // in-progress snapshots by request ID
+	synced     chan struct{}             // closed when the first snapshot completes
+	syncOnce   sync.Once
+
+	fills map[string]*models.FillTracker // fill executions seen, by order ID
+
+	pendingRisk map[string]bool            // orders seen in ORDER_STATE_PENDING_RISK
+	riskErrs    map[string]*RiskLimitError // risk check rejections, by order ID
+
+	reconciling map[string]bool // orders updated live during Reconcile; nil outside Reconcile
+
+	refreshUpdated map[string]bool // orders updated live while their REST refresh was in flight
+}
+
+// orderSnapshot collects a multi-message order snapshot until EOF.
+type orderSnapshot struct {
+	orders  []models.Order
+	updated map[string]bool // orders with live updates since the snapshot began
+}
+
+// newOrderCache creates an empty cache that refreshes through rest.
+func newOrderCache(rest *RestClient) *OrderCache {
+	return &OrderCache{
+		rest:        rest,
+		orders:      make(map[string]models.Order),
+		refreshing:  make(map[string]bool),
+		fills:       make(map[string]*models.FillTracker),
+		pendingRisk: make(map[string]bool),
+		riskErrs:    make(map[string]*RiskLimitError),
+		changed:     make(chan struct{}),
+		snapshots:   make(map[string]*orderSnapshot),
+		synced:      make(chan struct{}),
+
+		refreshUpdated: make(map[string]bool),
+	}
+}
+
+// observe applies order snapshots and execution updates.
+func (c *OrderCache) observe(msg *models.WSMessage) {
+	if snap := msg.OrderSubscriptionSnapshot; snap != nil {
+		c.applySnapshot(msg.RequestID, snap)
+	}
+	if u := msg.OrderSubscriptionUpdate; u != nil && u.Execution != nil && u.Execution.Order != nil {
+		c.mu.Lock()
+		for _, s := range c.snapshots {
+			s.updated[u.Execution.Order.ID] = true
+		}
+		if c.reconciling != nil {
+			c.reconciling[u.Execution.Order.ID] = true
+		}
+		if c.refreshing[u.Execution.Order.ID] {
+			c.refreshUpdated[u.Execution.Order.ID] = true
+		}
+		c.mu.Unlock()
+		c.trackFill(u.Execution)
+		c.trackRisk(u.Execution)
+		c.apply(*u.Execution.Order)
+	}
+}
+
+// trackFill adds a fill execution to its order's average price and checks
+// the result against the server's AvgPx once every fill has been seen.
+func (c *OrderCache) trackFill(e *models.Execution) {
+	o := e.Order
+	c.mu.Lock()
+	t, ok := c.fills[o.ID]
+	if !ok {
+		t = models.NewFillTracker()
+		c.fills[o.ID] = t
+	}
+	counted, err := t.Add(e)
+	avg, filled := t.AveragePrice()
+	complete := filled && trackedAll(t, o)
+	c.mu.Unlock()
+
+	if err != nil {
+		log.Printf("[WS] Failed to track fill for order %s: %v", o.ID, err)
+		return
+	}
+	if counted && complete && !o.AvgPxMatches(avg) {
+		log.Printf("[WS] Average fill price for order %s is %s from executions but %s from the server",
+			o.ID, avg.FloatString(6), o.AvgPx.Value)
+	}
+}
+
+// trackedAll reports whether t covers every share o has filled, i.e. no fill
+// happened before the cache started tracking the order.
+func trackedAll(t *models.FillTracker, o *models.Order) bool {
+	qty, _ := t.Qty.Float64()
+	return math.Abs(qty-o.CumQuantity) <= fillTolerance
+}
+
+// fillTolerance absorbs float rounding when comparing filled quantities.
+const fillTolerance = 1e-9
+
+// AverageFillPrice returns the volume-weighted average fill price of an
+// order. It is computed from the fill executions seen on the stream when
+// they account for the order's whole CumQuantity, and otherwise (fills
+// before the subscription began, or missed updates) taken from the server's
+// AvgPx on the cached order.
+func (c *OrderCache) AverageFillPrice(orderID string) (*big.Rat, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o, ok := c.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %s not in cache", orderID)
+	}
+	if t, ok := c.fills[orderID]; ok {
+		if avg, filled := t.AveragePrice(); filled && trackedAll(t, &o) {
+			return avg, nil
+		}
+	}
+	return o.AverageFillPrice()
+}
+
+// applySnapshot buffers one snapshot message and, on EOF, stores the
+// snapshot's orders except those updated live since it began.
+func (c *OrderCache) applySnapshot(requestID string, snap *models.OrderSnapshot) {
+	c.mu.Lock()
+	s, ok := c.snapshots[requestID]
+	if !ok {
+		s = &orderSnapshot{updated: make(map[string]bool)}
+		c.snapshots[requestID] = s
+	}
+	s.orders = append(s.orders, snap.Orders...)
+	if !snap.EOF {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.snapshots, requestID)
+
+	var stored []models.Order
+	for _, o := range s.orders {
+		if s.updated[o.ID] {
+			continue
+		}
+		c.store(o)
+		stored = append(stored, o)
+	}
+	c.mu.Unlock()
+
+	c.syncOnce.Do(func() { close(c.synced) })
+	for _, o := range stored {
+		if err := o.Validate(); err != nil {
+			log.Printf("[WS] Inconsistent order in snapshot, refreshing: %v", err)
+			c.refresh(o.ID)
+		}
+	}
+}
+
+// apply stores o, scheduling a REST refresh if it is inconsistent.
+func (c *OrderCache) apply(o models.Order) {
+	c.mu.Lock()
+	c.store(o)
+	c.mu.Unlock()
+
+	if err := o.Validate(); err != nil {
+		log.Printf("[WS] Inconsistent order update, refreshing: %v", err)
+		c.refresh(o.ID)
+	}
+}
+
+// refresh replaces the cached order with the server's copy. Concurrent
+// refreshes of the same order are coalesced. If a live update for the order
+// arrives while the request is in flight, the REST copy may predate it and
+// is discarded.
+func (c *OrderCache) refresh(orderID string) {
+	c.mu.Lock()
+	if c.refreshing[orderID] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[orderID] = true
+	c.mu.Unlock()
+
+	go func() {
+		resp, err := c.rest.GetOrder(orderID)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.refreshing, orderID)
+		updated := c.refreshUpdated[orderID]
+		delete(c.refreshUpdated, orderID)
+		if err != nil {
+			log.Printf("[WS] Failed to refresh order %s: %v", orderID, err)
+			return
+		}
+		if updated {
+			return
+		}
+		if resp.Order != nil {
+			c.store(*resp.Order)
+		}
+	}()
+}
+
+// store records o and wakes waiters. Callers must hold c.mu.
+func (c *OrderCache) store(o models.Order) {
+	c.orders[o.ID] = o
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Synced returns a channel that is closed once the first complete order
+// snapshot has been applied. Before that, Open may be missing orders.
+func (c *OrderCache) Synced() <-chan struct{} {
+	return c.synced
+}
+
+// Get returns the cached order with the given ID, marked expired if its
+// GoodTillTime has passed.
+func (c *OrderCache) Get(orderID string) (models.Order, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o, ok := c.orders[orderID]
+	return expireLocally(o, time.Now()), ok
+}
+
+// Open returns the cached orders that are not in a terminal state, leaving
+// out those whose GoodTillTime has passed.
+func (c *OrderCache) Open() []models.Order {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	var open []models.Order
+	for _, o := range c.orders {
+		if o = expireLocally(o, now); !o.State.IsTerminal() {
+			open = append(open, o)
+		}
+	}
+	return open
+}
+
+// expireLocally returns o as ORDER_STATE_EXPIRED with no leaves if it is
+// live but past its GoodTillTime, and o unchanged otherwise.
+func expireLocally(o models.Order, now time.Time) models.Order {
+	if o.State != models.OrderStateExpired && o.IsExpired(now) {
+		o.State = models.OrderStateExpired
+		o.LeavesQuantity = 0
+	}
+	return o
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// orderUpdate builds an order stream message carrying o.
+func orderUpdate(execType models.ExecutionType, o models.Order) *models.WSMessage {
+	return &models.WSMessage{OrderSubscriptionUpdate: &models.OrderUpdate{
+		Execution: &models.Execution{ID: "exec-" + string(o.State), Type: execType, Order: &o},
+	}}
+}
+
+// waitRefreshed waits until no REST refresh of orderID is in flight.
+func waitRefreshed(t *testing.T, cache *OrderCache, orderID string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		cache.mu.Lock()
+		busy := cache.refreshing[orderID]
+		cache.mu.Unlock()
+		if !busy {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("refresh of %s still in flight", orderID)
+}
+
+func TestOrderCacheRefreshKeepsLiveUpdate(t *testing.T) {
+	stale := `{"order":{"id":"order-1","marketSlug":"test-market","quantity":10,"cumQuantity":0,` +
+		`"leavesQuantity":10,"state":"ORDER_STATE_PENDING_NEW"}}`
+	tests := []struct {
+		name      string
+		liveWhile bool // deliver a live update while the refresh is in flight
+		wantState models.OrderState
+	}{
+		{"no live update", false, models.OrderStatePendingNew},
+		{"live update during refresh", true, models.OrderStateFilled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := make(chan struct{})
+			release := make(chan struct{})
+			c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(requested)
+				<-release
+				w.Write([]byte(stale))
+			}), WithMaxRetries(0))
+			cache := newOrderCache(c)
+
+			// Leaves do not add up, so the update triggers a refresh.
+			cache.observe(orderUpdate(models.ExecutionTypePartialFill, models.Order{
+				ID: "order-1", MarketSlug: "test-market", Quantity: 10, CumQuantity: 6, LeavesQuantity: 10,
+				State: models.OrderStatePartiallyFilled,
+			}))
+			<-requested
+			if tt.liveWhile {
+				cache.observe(orderUpdate(models.ExecutionTypeFill, models.Order{
+					ID: "order-1", MarketSlug: "test-market", Quantity: 10, CumQuantity: 10,
+					State: models.OrderStateFilled,
+				}))
+			}
+			close(release)
+			waitRefreshed(t, cache, "order-1")
+
+			got, ok := cache.Get("order-1")
+			if !ok {
+				t.Fatal("order-1 missing from cache")
+			}
+			if got.State != tt.wantState {
+				t.Errorf("cached state = %s, want %s", got.State, tt.wantState)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// cancelConfirmAttempts bounds how often CancelReplace polls the old order
+// while waiting for the cancel to take effect.
+const cancelConfirmAttempts = 5
+
+// CancelReplace cancels orderID and places newReq in its place, returning the
+// new order.
+//
+// The API has no atomic replace endpoint, so this is a cancel-then-create
+// sequence. There is a window in which no order rests on the book; to avoid
+// a double fill, the old order is confirmed terminal before the replacement
+// is sent, and the replacement is skipped if the old order traded while the
+// cancel was in flight. The replacement is submitted with synchronous
+// execution so its immediate outcome is known when this returns.
+// Doc: api-reference/orders/overview.mdx - POST /v1/order/{orderId}/cancel, POST /v1/orders
+func (c *RestClient) CancelReplace(orderID string, newReq *models.CreateOrderRequest) (*models.Order, error) {
+	if err := newReq.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid replacement order: %w", err)
+	}
+
+	before, err := c.GetOrder(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %s: %w", orderID, err)
+	}
+	if before.Order == nil {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	if before.Order.State.IsTerminal() {
+		return nil, fmt.Errorf("order %s is already %s", orderID, before.Order.State)
+	}
+
+	if err := c.CancelOrder(orderID, before.Order.MarketSlug); err != nil {
+		return nil, fmt.Errorf("failed to cancel order %s: %w", orderID, err)
+	}
+
+	after, err := c.awaitTerminal(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if after.CumQuantity > before.Order.CumQuantity {
+		return nil, fmt.Errorf("order %s filled %.0f while being canceled; replacement not placed",
+			orderID, after.CumQuantity-before.Order.CumQuantity)
+	}
+
+	replacement := *newReq
+	replacement.SynchronousExecution = true
+
+	resp, err := c.CreateOrder(&replacement)
+	if err != nil {
+		return nil, fmt.Errorf("order %s canceled but replacement failed: %w", orderID, err)
+	}
+
+	placed, err := c.GetOrder(resp.ID)
+	if err != nil || placed.Order == nil {
+		// The order was accepted; return what we know rather than an error.
+		return &models.Order{ID: resp.ID, MarketSlug: replacement.MarketSlug}, nil
+	}
+	return placed.Order, nil
+}
+
+// awaitTerminal polls an order until it reaches a terminal state.
+func (c *RestClient) awaitTerminal(orderID string) (*models.Order, error) {
+	delay := 100 * time.Millisecond
+	for i := 0; i < cancelConfirmAttempts; i++ {
+		resp, err := c.GetOrder(orderID)
+		if err == nil && resp.Order != nil && resp.Order.State.IsTerminal() {
+			return resp.Order, nil
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+	return nil, fmt.Errorf("order %s not confirmed canceled; replacement not placed", orderID)
+}
+
+// Backoff bounds for AwaitOrder polling.
+const (
+	awaitOrderBaseDelay = 100 * time.Millisecond
+	awaitOrderMaxDelay  = 2 * time.Second
+)
+
+// AwaitOrder polls GetOrder with exponential backoff until until reports
+// true for the order, and returns that order. A nil until waits for the
+// order to become queryable. Use it after CreateOrder instead of sleeping
+// when the order stream is not subscribed:
+//
+//	o, err := rest.AwaitOrder(ctx, resp.ID, func(o *models.Order) bool {
+//		return o.State != models.OrderStatePendingNew
+//	})
+//
+// A 404 is treated as the order not yet being queryable and polling
+// continues; other errors are returned. If ctx ends first, the last order
+// seen, if any, is returned along with the context error.
+// Doc: api-reference/orders/overview.mdx - GET /v1/order/{orderId}
+func (c *RestClient) AwaitOrder(ctx context.Context, orderID string, until func(*models.Order) bool) (*models.Order, error) {
+	delay := awaitOrderBaseDelay
+	var last *models.Order
+	for {
+		resp, err := c.GetOrderContext(ctx, orderID)
+		var apiErr *APIError
+		switch {
+		case err == nil && resp.Order != nil:
+			last = resp.Order
+			if until == nil || until(last) {
+				return last, nil
+			}
+		case err == nil, errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			// Not yet queryable.
+		case ctx.Err() != nil:
+		default:
+			return last, fmt.Errorf("failed to get order %s: %w", orderID, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, fmt.Errorf("order %s: %w", orderID, ctx.Err())
+		case <-timer.C:
+		}
+		delay = min(delay*2, awaitOrderMaxDelay)
+	}
+}
+
+// InsufficientBuyingPowerError reports that an order's estimated notional
+// exceeds the account's buying power.
+type InsufficientBuyingPowerError struct {
+	Required  *models.Amount
+	Available *models.Amount
+}
+
+func (e *InsufficientBuyingPowerError) Error() string {
+	return fmt.Sprintf("insufficient buying power: order requires %s, available %s",
+		e.Required.Format(2), e.Available.Format(2))
+}
+
+// CheckBuyingPower estimates the cash req would consume and compares it with
+// the buying power of the balance in the order's price currency (see
+// models.EstimateBuyingPowerImpact), as reported by GetBalances, returning an
+// *InsufficientBuyingPowerError when the order cannot be covered. Run it
+// before CreateOrder to catch rejections locally.
+//
+// Note: the balance can change between the check and the order; the server
+// remains authoritative.
+// Doc: api-reference/account/overview.mdx - GET /v1/account/balances
+func (c *RestClient) CheckBuyingPower(req *models.CreateOrderRequest) error {
+	required, err := models.EstimateBuyingPowerImpact(req)
+	if err != nil {
+		return fmt.Errorf("failed to estimate order notional: %w", err)
+	}
+	need, err := required.Rat()
+	if err != nil {
+		return err
+	}
+	if need.Sign() == 0 {
+		return nil
+	}
+
+	balances, err := c.GetBalances()
+	if err != nil {
+		return fmt.Errorf("failed to get balances: %w", err)
+	}
+	balance, ok := balances.BalanceByCurrency(required.Currency)
+	if !ok {
+		return fmt.Errorf("no %s balance for account (have %s)",
+			required.Currency, strings.Join(balances.Currencies(), ", "))
+	}
+
+	available := new(big.Rat).SetFloat64(balance.BuyingPower)
+	if available == nil || need.Cmp(available) > 0 {
+		return &InsufficientBuyingPowerError{
+			Required: required,
+			Available: &models.Amount{
+				Value:    strconv.FormatFloat(balance.BuyingPower, 'f', -1, 64),
+				Currency: balance.Currency,
+			},
+		}
+	}
+	return nil
+}
+
+// CancelOrdersOlderThan cancels every open order placed more than d ago and
+// returns the IDs of the canceled orders, oldest first. An order's age is
+// taken from its CreateTime, or InsertTime when CreateTime is missing.
+//
+// Orders whose timestamps cannot be parsed are skipped, as are orders that
+// fail to cancel; both are reported in the returned error, alongside the
+// IDs that were canceled.
+// Doc: api-reference/orders/overview.mdx - GET /v1/orders/open, POST /v1/order/{orderId}/cancel
+func (c *RestClient) CancelOrdersOlderThan(d time.Duration) ([]string, error) {
+	open, err := c.GetOpenOrders(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	cutoff := time.Now().Add(-d)
+	var (
+		stale   []models.Order
+		placed  = make(map[string]time.Time)
+		skipped []error
+	)
+	for _, o := range open.Orders {
+		at, err := orderPlacedAt(&o)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("skipped order %s: %w", o.ID, err))
+			continue
+		}
+		if at.Before(cutoff) {
+			stale = append(stale, o)
+			placed[o.ID] = at
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		return placed[stale[i].ID].Before(placed[stale[j].ID])
+	})
+
+	cancelErrs := make([]error, len(stale))
+	forEach(context.Background(), c.concurrency, len(stale), func(_ context.Context, i int) {
+		if err := c.CancelOrder(stale[i].ID, stale[i].MarketSlug); err != nil {
+			cancelErrs[i] = fmt.Errorf("failed to cancel order %s: %w", stale[i].ID, err)
+		}
+	})
+
+	var canceled []string
+	for i, o := range stale {
+		if cancelErrs[i] == nil {
+			canceled = append(canceled, o.ID)
+		}
+	}
+	return canceled, errors.Join(append(skipped, cancelErrs...)...)
+}
+
+// orderPlacedAt returns when o was placed: its CreateTime, or its InsertTime
+// when CreateTime is missing.
+func orderPlacedAt(o *models.Order) (time.Time, error) {
+	if o.CreateTime != "" {
+		return o.CreateTimeParsed()
+	}
+	if o.InsertTime != "" {
+		return o.InsertTimeParsed()
+	}
+	return time.Time{}, fmt.Errorf("no create or insert time")
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// twoCurrencyHandler serves an account holding USD and EUR balances, one
+// position valued in each currency, and no open orders.
+var twoCurrencyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/account/balances"):
+		w.Write([]byte(`{"balances":[
+			{"currency":"USD","currentBalance":120,"buyingPower":100,"openOrders":20,
+			 "pendingWithdrawals":[{"balance":5},{"balance":2.5}]},
+			{"currency":"EUR","currentBalance":10,"buyingPower":5}]}`))
+	case strings.HasSuffix(r.URL.Path, "/portfolio/positions"):
+		w.Write([]byte(`{"positions":{
+			"usd-market":{"netPosition":"10","cashValue":{"value":"6","currency":"USD"}},
+			"eur-market":{"netPosition":"4","cashValue":{"value":"2","currency":"EUR"}}},"eof":true}`))
+	case strings.HasSuffix(r.URL.Path, "/orders/open"):
+		w.Write([]byte(`{"orders":[]}`))
+	default:
+		http.NotFound(w, r)
+	}
+})
+
+func TestCheckBuyingPowerTwoCurrencies(t *testing.T) {
+	c := newTestRestClient(t, twoCurrencyHandler)
+	tests := []struct {
+		name         string
+		currency     string
+		wantErr      bool
+		wantShortage bool
+	}{
+		{"covered in USD", "USD", false, false},
+		{"empty currency means USD", "", false, false},
+		{"short in EUR", "EUR", true, true},
+		{"no GBP balance", "GBP", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := &models.Amount{Value: "0.5", Currency: tt.currency}
+			req := models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 100)
+			err := c.CheckBuyingPower(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckBuyingPower = %v, wantErr %v", err, tt.wantErr)
+			}
+			var shortage *InsufficientBuyingPowerError
+			if got := errors.As(err, &shortage); got != tt.wantShortage {
+				t.Errorf("CheckBuyingPower = %v, want InsufficientBuyingPowerError: %v", err, tt.wantShortage)
+			}
+			if shortage != nil && shortage.Available.Currency != tt.currency {
+				t.Errorf("Available currency = %q, want %q", shortage.Available.Currency, tt.currency)
+			}
+		})
+	}
+}
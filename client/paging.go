@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// PageOptions bounds the All* fetch-all helpers.
+type PageOptions struct {
+	// PageSize is the limit sent with each request; 0 uses 100.
+	PageSize int
+
+	// MaxItems stops fetching once this many items have been collected;
+	// 0 fetches everything. The result never exceeds MaxItems.
+	MaxItems int
+
+	// OnPage, if set, is called after each page with the 1-based page
+	// number and the number of items collected so far, to report progress.
+	OnPage func(page, collected int)
+}
+
+func (o PageOptions) pageSize() int {
+	if o.PageSize > 0 {
+		return o.PageSize
+	}
+	return 100
+}
+
+// full reports whether collected has reached MaxItems.
+func (o PageOptions) full(collected int) bool {
+	return o.MaxItems > 0 && collected >= o.MaxItems
+}
+
+func (o PageOptions) reportPage(page, collected int) {
+	if o.OnPage != nil {
+		o.OnPage(page, collected)
+	}
+}
+
+// AllPositions follows the positions cursor and returns the merged map of
+// market slug to position, optionally filtered to one market.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions, Pagination
+func (c *RestClient) AllPositions(ctx context.Context, market string, opts PageOptions) (map[string]models.UserPosition, error) {
+	all := make(map[string]models.UserPosition)
+	cursor := ""
+	for page := 1; ; page++ {
+		resp, err := c.GetPositionsContext(ctx, market, opts.pageSize(), cursor)
+		if err != nil {
+			return nil, err
+		}
+		for slug, p := range resp.Positions {
+			if opts.full(len(all)) {
+				break
+			}
+			all[slug] = p
+		}
+		opts.reportPage(page, len(all))
+		if opts.full(len(all)) || resp.EOF || resp.NextCursor == "" {
+			return all, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// AllActivities follows the activities cursor and returns the combined
+// history, filtered as in GetActivities.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities, Pagination
+func (c *RestClient) AllActivities(ctx context.Context, marketSlug string, types []string, sortOrder string, opts PageOptions) ([]models.Activity, error) {
+	var all []models.Activity
+	cursor := ""
+	for page := 1; ; page++ {
+		resp, err := c.GetActivitiesContext(ctx, marketSlug, types, opts.pageSize(), cursor, sortOrder)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Activities...)
+		if opts.full(len(all)) {
+			all = all[:opts.MaxItems]
+		}
+		opts.reportPage(page, len(all))
+		if opts.full(len(all)) || resp.EOF || resp.NextCursor == "" {
+			return all, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// AllMarkets pages through markets with offset until a short page, filtered
+// by active status when active is non-nil.
+// Doc: api-reference/market/overview.mdx - GET /v1/markets, Pagination & Ordering
+func (c *RestClient) AllMarkets(ctx context.Context, active *bool, opts PageOptions) ([]models.Market, error) {
+	var all []models.Market
+	size := opts.pageSize()
+	for page := 1; ; page++ {
+		resp, err := c.QueryMarketsContext(ctx, MarketsQuery{
+			Limit:  size,
+			Offset: (page - 1) * size,
+			Active: active,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Markets...)
+		if opts.full(len(all)) {
+			all = all[:opts.MaxItems]
+		}
+		opts.reportPage(page, len(all))
+		if opts.full(len(all)) || len(resp.Markets) < size {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultConcurrency is how many requests a fan-out operation runs at once
+// unless WithConcurrency says otherwise.
+const defaultConcurrency = 4
+
+// forEach calls fn(ctx, i) for every i in [0, n) on at most limit goroutines
+// and waits for them to finish. Once ctx ends, no further calls are started
+// and ctx.Err() is returned; calls already running see the same ctx. Results
+// and per-item errors are for fn to record, typically into a slice indexed
+// by i. Every fan-out in this package goes through forEach so a large batch
+// never spawns more than limit goroutines.
+func forEach(ctx context.Context, limit, n int, fn func(ctx context.Context, i int)) error {
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+	limit = min(limit, n)
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(limit)
+	for w := 0; w < limit; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		case next <- i:
+		}
+	}
+	close(next)
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// activityPageSize is the page size used when scanning activity history.
+const activityPageSize = 100
+
+// GetActivity looks up a single activity by ID, matching a trade ID, an
+// account balance change transaction ID, or the trade ID of a position
+// resolution.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities
+//
+// Note: the portfolio API has no single-activity endpoint, so this pages
+// through activity history newest-first and stops at the first match.
+func (c *RestClient) GetActivity(id string) (*models.Activity, error) {
+	cursor := ""
+	for {
+		resp, err := c.GetActivities("", nil, activityPageSize, cursor, "")
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Activities {
+			if resp.Activities[i].ID() == id {
+				return &resp.Activities[i], nil
+			}
+		}
+		if resp.EOF || resp.NextCursor == "" {
+			return nil, fmt.Errorf("activity %s not found", id)
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// GetTrade looks up a single trade by its trade ID.
+// See GetActivity for how the lookup is performed.
+func (c *RestClient) GetTrade(tradeID string) (*models.Trade, error) {
+	activity, err := c.GetActivity(tradeID)
+	if err != nil {
+		return nil, err
+	}
+	trade, ok := activity.AsTrade()
+	if !ok {
+		return nil, fmt.Errorf("activity %s is not a trade", tradeID)
+	}
+	return trade, nil
+}
+
+// maxPollBackoff caps how far PollPositions stretches its interval after
+// consecutive errors.
+const maxPollBackoff = time.Minute
+
+// PollPositions fetches the full position set every interval and passes it to
+// callback, until ctx is done. It is a REST-only alternative to the
+// positions WebSocket subscription.
+//
+// Each cycle follows the cursor to EOF, so callback always receives a
+// complete snapshot keyed by market slug. A failed cycle calls callback with
+// a nil map and the error, then waits twice as long as before (up to one
+// minute, and never less than interval) before retrying; a successful cycle
+// restores the normal interval. PollPositions returns ctx.Err() once ctx is
+// done.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions
+func (c *RestClient) PollPositions(ctx context.Context, interval time.Duration, callback func(map[string]models.UserPosition, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("poll interval must be positive, got %s", interval)
+	}
+
+	wait := interval
+	for {
+		positions, err := c.getAllPositionsContext(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		callback(positions, err)
+
+		if err != nil {
+			wait *= 2
+			if wait > maxPollBackoff {
+				wait = max(maxPollBackoff, interval)
+			}
+		} else {
+			wait = interval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
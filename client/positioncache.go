@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// PositionCache holds the latest known position per market, seeded from
+// REST by Sync and kept current by the private position stream. Each update
+// is applied according to its entry type (see PositionUpdate.Apply): order
+// executions and resolutions replace the position, or adjust NetPosition
+// when the update omits it, while cash-only entries leave it unchanged.
+//
+// The position stream sends no snapshot, so call Sync after subscribing;
+// markets updated on the stream while Sync is in flight keep the update.
+// Doc: api-reference/websocket/private.mdx - Position Subscriptions
+type PositionCache struct {
+	rest *RestClient
+
+	mu        sync.Mutex
+	positions map[string]models.UserPosition // keyed by market slug
+	syncing   map[string]bool                // markets updated live during Sync; nil outside Sync
+}
+
+// newPositionCache creates an empty cache that syncs through rest.
+func newPositionCache(rest *RestClient) *PositionCache {
+	return &PositionCache{
+		rest:      rest,
+		positions: make(map[string]models.UserPosition),
+	}
+}
+
+// observe applies position stream updates.
+func (c *PositionCache) observe(msg *models.WSMessage) {
+	if u := msg.PositionSubscription; u != nil {
+		if err := c.apply(u); err != nil {
+			log.Printf("[WS] Failed to apply position update: %v", err)
+		}
+	}
+}
+
+// apply applies one update to the cached position for its market.
+func (c *PositionCache) apply(u *models.PositionUpdate) error {
+	if u.Apply() == models.PositionApplyNone {
+		return nil
+	}
+	slug := u.MarketSlug()
+	if slug == "" {
+		return fmt.Errorf("%s update has no market slug", u.EntryType)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var cur *models.UserPosition
+	if p, ok := c.positions[slug]; ok {
+		cur = &p
+	}
+	next, err := u.ApplyTo(cur)
+	if err != nil {
+		return fmt.Errorf("%s: %w", slug, err)
+	}
+	if c.syncing != nil {
+		c.syncing[slug] = true
+	}
+	c.positions[slug] = next
+	return nil
+}
+
+// Sync loads every position over REST, replacing cached positions except
+// those updated on the stream since Sync began. Cached markets absent from
+// the REST result, i.e. positions closed while the stream was down, are
+// dropped unless they too were updated on the stream.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions
+func (c *PositionCache) Sync(ctx context.Context) error {
+	c.mu.Lock()
+	c.syncing = make(map[string]bool)
+	c.mu.Unlock()
+
+	positions, err := c.rest.getAllPositionsContext(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	updated := c.syncing
+	c.syncing = nil
+	if err != nil {
+		return fmt.Errorf("failed to get positions: %w", err)
+	}
+	for slug, p := range positions {
+		if !updated[slug] {
+			c.positions[slug] = p
+		}
+	}
+	for slug := range c.positions {
+		if _, ok := positions[slug]; !ok && !updated[slug] {
+			delete(c.positions, slug)
+		}
+	}
+	return nil
+}
+
+// Get returns the cached position in a market.
+func (c *PositionCache) Get(slug string) (models.UserPosition, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.positions[slug]
+	return p, ok
+}
+
+// All returns a copy of every cached position, keyed by market slug.
+func (c *PositionCache) All() map[string]models.UserPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	all := make(map[string]models.UserPosition, len(c.positions))
+	for slug, p := range c.positions {
+		all[slug] = p
+	}
+	return all
+}
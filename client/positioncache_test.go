@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// positionUpdate builds a position stream message for test-market.
+func positionUpdate(entryType string, before, after *models.UserPosition) *models.WSMessage {
+	meta := &models.MarketMetadata{Slug: "test-market"}
+	if before != nil {
+		before.MarketMetadata = meta
+	}
+	if after != nil {
+		after.MarketMetadata = meta
+	}
+	return &models.WSMessage{PositionSubscription: &models.PositionUpdate{
+		EntryType:      entryType,
+		BeforePosition: before,
+		AfterPosition:  after,
+	}}
+}
+
+func TestPositionCacheSnapshotThenUpdates(t *testing.T) {
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"positions":{"test-market":{"netPosition":"10","qtyBought":"10","qtySold":"0"}},"eof":true}`))
+	}))
+	cache := newPositionCache(c)
+	if err := cache.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	steps := []struct {
+		name    string
+		msg     *models.WSMessage
+		wantNet string
+	}{
+		{
+			name: "adjust buy",
+			msg: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{QtyBought: "10", QtySold: "0"},
+				&models.UserPosition{QtyBought: "15", QtySold: "0"}),
+			wantNet: "15",
+		},
+		{
+			name: "adjust sell",
+			msg: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{QtyBought: "15", QtySold: "0"},
+				&models.UserPosition{QtyBought: "15", QtySold: "3.5"}),
+			wantNet: "11.5",
+		},
+		{
+			name: "cash only",
+			msg: positionUpdate(models.LedgerEntryTypeCommission,
+				nil, &models.UserPosition{NetPosition: "99"}),
+			wantNet: "11.5",
+		},
+		{
+			name: "replace",
+			msg: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{NetPosition: "11.5"}, &models.UserPosition{NetPosition: "8"}),
+			wantNet: "8",
+		},
+		{
+			name: "adjust after replace",
+			msg: positionUpdate(models.LedgerEntryTypeOrderExecution,
+				&models.UserPosition{QtyBought: "15", QtySold: "7"},
+				&models.UserPosition{QtyBought: "15", QtySold: "9"}),
+			wantNet: "6",
+		},
+	}
+	for _, step := range steps {
+		cache.observe(step.msg)
+		p, ok := cache.Get("test-market")
+		if !ok {
+			t.Fatalf("%s: no cached position", step.name)
+		}
+		if p.NetPosition != step.wantNet {
+			t.Errorf("%s: NetPosition = %q, want %q", step.name, p.NetPosition, step.wantNet)
+		}
+	}
+}
+
+func TestPositionCacheSyncDropsClosedPositions(t *testing.T) {
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"positions":{"open-market":{"netPosition":"5"}},"eof":true}`))
+	}))
+	cache := newPositionCache(c)
+	cache.positions["open-market"] = models.UserPosition{NetPosition: "3"}
+	cache.positions["closed-market"] = models.UserPosition{NetPosition: "7"}
+
+	if err := cache.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if _, ok := cache.Get("closed-market"); ok {
+		t.Error("closed-market still cached after Sync, want it dropped")
+	}
+	if p, ok := cache.Get("open-market"); !ok || p.NetPosition != "5" {
+		t.Errorf("open-market = %+v, %v, want NetPosition 5", p, ok)
+	}
+}
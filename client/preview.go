@@ -0,0 +1,178 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// ErrNoPreviewPrice is returned by PreviewAndPlace when the preview carries
+// no estimated price, which usually means the book is too thin to fill the
+// order. Nothing is placed.
+var ErrNoPreviewPrice = errors.New("order preview returned no estimated price")
+
+// SlippageExceededError is returned by PreviewAndPlace when the previewed
+// execution price is worse than the reference price by more than the
+// tolerance. Nothing is placed.
+type SlippageExceededError struct {
+	Reference   *models.Amount // Limit price, or top of book for market orders
+	Estimated   *models.Amount // Preview's average (or limit) price
+	MaxSlippage *models.Amount
+}
+
+func (e *SlippageExceededError) Error() string {
+	return fmt.Sprintf("estimated price %s is more than %s worse than reference %s",
+		e.Estimated.ValueOr("N/A"), e.MaxSlippage.ValueOr("N/A"), e.Reference.ValueOr("N/A"))
+}
+
+// PreviewAndPlace previews req and places it only if the previewed price is
+// within maxSlippage of a reference price, narrowing the window in which the
+// market can move between preview and placement.
+//
+// The reference is req.Price for limit orders. For orders without a price it
+// is the top of the book: the best ask for Buy Yes, the best bid for Sell
+// Yes, and their complements (1 - price) for Buy No and Sell No. Slippage is
+// only counted in the adverse direction (higher for buys, lower for sells).
+// Returns ErrNoPreviewPrice or *SlippageExceededError without placing the
+// order; otherwise the result is that of CreateOrder.
+//
+// With WithPreviewRounding, the order placed is a copy of req carrying the
+// preview's RoundedPrice, so the price submitted is the one previewed and
+// checked for slippage; req itself is left unchanged.
+//
+// Note: the market can still move between the preview and the order; use a
+// limit price to cap the execution price outright.
+// Doc: api-reference/orders/overview.mdx - POST /v1/order/preview, POST /v1/orders
+func (c *RestClient) PreviewAndPlace(req *models.CreateOrderRequest, maxSlippage *models.Amount) (*models.CreateOrderResponse, error) {
+	tolerance, err := maxSlippage.Rat()
+	if err != nil || tolerance.Sign() < 0 {
+		return nil, fmt.Errorf("invalid max slippage %s", maxSlippage.ValueOr("nil"))
+	}
+
+	preview, err := c.PreviewOrder(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview order: %w", err)
+	}
+	if preview.Order == nil {
+		return nil, ErrNoPreviewPrice
+	}
+	estimated := preview.Order.AvgPx
+	if estimated == nil || estimated.Value == "" {
+		estimated = preview.Order.Price
+	}
+	if estimated == nil || estimated.Value == "" {
+		return nil, ErrNoPreviewPrice
+	}
+	est, err := estimated.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid preview price: %w", err)
+	}
+
+	placed := req
+	if preview.RoundedPrice != nil {
+		copied := *req
+		copied.Price = preview.RoundedPrice
+		placed = &copied
+	}
+
+	reference, err := c.referencePrice(placed)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := reference.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference price: %w", err)
+	}
+
+	slippage := new(big.Rat).Sub(est, ref)
+	if !isBuyIntent(placed.Intent) {
+		slippage.Neg(slippage)
+	}
+	if slippage.Cmp(tolerance) > 0 {
+		return nil, &SlippageExceededError{Reference: reference, Estimated: estimated, MaxSlippage: maxSlippage}
+	}
+
+	return c.CreateOrder(placed)
+}
+
+// referencePrice returns req's limit price, or the relevant top of book for
+// orders without one.
+func (c *RestClient) referencePrice(req *models.CreateOrderRequest) (*models.Amount, error) {
+	if req.Price != nil {
+		return req.Price, nil
+	}
+
+	book, err := c.GetOrderBook(req.MarketSlug, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book for reference price: %w", err)
+	}
+	level := book.BestAsk()
+	if req.Intent == models.OrderIntentRequestSellYes || req.Intent == models.OrderIntentRequestBuyNo {
+		level = book.BestBid()
+	}
+	if level == nil || level.Px == nil {
+		return nil, fmt.Errorf("no reference price: order book for %s has an empty side", req.MarketSlug)
+	}
+	if req.Intent == models.OrderIntentRequestBuyYes || req.Intent == models.OrderIntentRequestSellYes {
+		return level.Px, nil
+	}
+
+	px, err := level.Px.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid book price: %w", err)
+	}
+	complement := new(big.Rat).Sub(big.NewRat(1, 1), px)
+	return models.NewAmountFromRat(complement, decimalPlaces(level.Px.Value), level.Px.Currency)
+}
+
+// isBuyIntent reports whether a request intent buys shares.
+func isBuyIntent(intent int) bool {
+	return intent == models.OrderIntentRequestBuyYes || intent == models.OrderIntentRequestBuyNo
+}
+
+// decimalPlaces returns the number of digits after the decimal point in s.
+func decimalPlaces(s string) int {
+	_, frac, _ := strings.Cut(s, ".")
+	return len(frac)
+}
+
+// roundToTick rounds price to slug's tick size using the market cache. It
+// returns the price to send and a warning when the value changed or the
+// price could not be rounded; in the latter case the price is sent as given
+// and the server remains authoritative.
+//
+// Note: the docs do not say how the server aligns an off-tick price; the
+// nearest tick is assumed, as in Market.RoundPrice.
+func (c *RestClient) roundToTick(slug string, price *models.Amount) (*models.Amount, string) {
+	m, err := c.markets.Market(slug)
+	if err != nil {
+		return price, fmt.Sprintf("price not rounded: %v", err)
+	}
+	tickSize := m.TickSize()
+	if tickSize == "" {
+		return price, fmt.Sprintf("price not rounded: market %s reports no tick size", slug)
+	}
+	rounded, err := m.RoundPrice(price)
+	if err != nil {
+		return price, fmt.Sprintf("price not rounded: %v", err)
+	}
+
+	want, err := price.Rat()
+	if err != nil {
+		return price, fmt.Sprintf("price not rounded: %v", err)
+	}
+	got, err := rounded.Rat()
+	if err != nil {
+		return price, fmt.Sprintf("price not rounded: %v", err)
+	}
+	switch {
+	case got.Sign() <= 0:
+		return price, fmt.Sprintf("price not rounded: %s rounds to %s at tick size %s", price.Value, rounded.Value, tickSize)
+	case got.Cmp(want) != 0:
+		return rounded, fmt.Sprintf("price %s rounded to %s at tick size %s", price.Value, rounded.Value, tickSize)
+	}
+	return rounded, ""
+}
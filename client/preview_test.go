@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+func TestPreviewAndPlaceSubmitsRoundedPrice(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		placed *models.CreateOrderRequest
+	)
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/market/slug/"):
+			w.Write([]byte(`{"slug":"test-market","active":true,"orderPriceMinTickSize":0.01}`))
+		case strings.HasSuffix(r.URL.Path, "/order/preview"):
+			w.Write([]byte(`{"order":{"id":"preview","price":{"value":"0.55","currency":"USD"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			body, _ := io.ReadAll(r.Body)
+			var req models.CreateOrderRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("order body: %v", err)
+			}
+			mu.Lock()
+			placed = &req
+			mu.Unlock()
+			w.Write([]byte(`{"id":"order-1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithPreviewRounding(true), WithMarketCheck(false))
+
+	price := &models.Amount{Value: "0.553", Currency: "USD"}
+	req := models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 10)
+	tolerance := &models.Amount{Value: "0", Currency: "USD"}
+	if _, err := c.PreviewAndPlace(req, tolerance); err != nil {
+		t.Fatalf("PreviewAndPlace: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if placed == nil || placed.Price == nil {
+		t.Fatalf("placed order = %+v, want a priced order", placed)
+	}
+	if placed.Price.Value != "0.55" {
+		t.Errorf("placed price = %s, want the rounded 0.55", placed.Price.Value)
+	}
+	if req.Price.Value != "0.553" {
+		t.Errorf("req price = %s, want it left at 0.553", req.Price.Value)
+	}
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// reconcileActivityLimit is how many recent trade activities Reconcile
+// checks against the cached positions.
+const reconcileActivityLimit = 100
+
+// CorrectionKind describes what Reconcile corrected.
+type CorrectionKind int
+
+const (
+	CorrectionOrderAdded     CorrectionKind = iota + 1 // Open on the server but missing from the cache
+	CorrectionOrderUpdated                             // Open on the server with a different state or quantities
+	CorrectionOrderClosed                              // Live in the cache but no longer open on the server
+	CorrectionPositionSynced                           // Traded during the gap; NetPosition reloaded from REST
+)
+
+// String returns a short label for the kind.
+func (k CorrectionKind) String() string {
+	switch k {
+	case CorrectionOrderAdded:
+		return "order_added"
+	case CorrectionOrderUpdated:
+		return "order_updated"
+	case CorrectionOrderClosed:
+		return "order_closed"
+	case CorrectionPositionSynced:
+		return "position_synced"
+	}
+	return "unknown"
+}
+
+// Correction is one discrepancy Reconcile found and fixed in the local
+// state. Order corrections carry the cached order (nil when it was missing)
+// and the server's copy that replaced it; position corrections carry the
+// position before and after the reload (nil when none was held).
+type Correction struct {
+	Kind       CorrectionKind
+	MarketSlug string
+	OrderID    string // Empty for CorrectionPositionSynced
+
+	Before, After                 *models.Order
+	BeforePosition, AfterPosition *models.UserPosition
+}
+
+// Reconcile brings Orders and Positions back in line with the server after
+// a gap in the private stream, typically on a ConnectionEventConnected
+// event for StreamPrivate following a disconnect:
+//
+//   - Orders open over REST but missing from the cache, or cached with a
+//     different state or quantities, are stored.
+//   - Orders live in the cache but no longer open over REST, i.e. filled,
+//     canceled, or expired during the gap, are fetched with GetOrder and
+//     stored in their final state.
+//   - Markets with a trade among the recent activities newer than the cached
+//     position trigger a Positions.Sync, and each such market whose
+//     NetPosition changed is reported.
+//
+// Orders updated on the stream while Reconcile is in flight keep the update.
+// Each correction is passed to OnCorrection, when set, and returned. Orders
+// that could not be fetched are reported in the joined error alongside the
+// corrections that were made.
+// Doc: api-reference/orders/overview.mdx - GET /v1/orders/open
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities
+func (c *Client) Reconcile(ctx context.Context) ([]Correction, error) {
+	orders := c.Orders
+	orders.mu.Lock()
+	orders.reconciling = make(map[string]bool)
+	orders.mu.Unlock()
+
+	corrections, err := c.reconcileOrders(ctx)
+
+	orders.mu.Lock()
+	orders.reconciling = nil
+	orders.mu.Unlock()
+
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if ctx.Err() == nil {
+		positions, err := c.reconcilePositions(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		corrections = append(corrections, positions...)
+	}
+
+	if c.OnCorrection != nil {
+		for _, corr := range corrections {
+			c.OnCorrection(corr)
+		}
+	}
+	return corrections, errors.Join(errs...)
+}
+
+// reconcileOrders diffs the cache against the open orders over REST and
+// fetches the final state of cached orders that are no longer open.
+func (c *Client) reconcileOrders(ctx context.Context) ([]Correction, error) {
+	resp, err := c.REST.GetOpenOrdersContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	var corrections []Correction
+	cache := c.Orders
+	cache.mu.Lock()
+	open := make(map[string]bool, len(resp.Orders))
+	for _, o := range resp.Orders {
+		open[o.ID] = true
+		if cache.reconciling[o.ID] {
+			continue
+		}
+		cur, ok := cache.orders[o.ID]
+		switch {
+		case !ok:
+			corrections = append(corrections, orderCorrection(CorrectionOrderAdded, nil, o))
+		case orderDiffers(cur, o):
+			corrections = append(corrections, orderCorrection(CorrectionOrderUpdated, &cur, o))
+		default:
+			continue
+		}
+		cache.store(o)
+	}
+	var closed []string
+	for id, o := range cache.orders {
+		if !open[id] && !o.State.IsTerminal() && !cache.reconciling[id] {
+			closed = append(closed, id)
+		}
+	}
+	cache.mu.Unlock()
+	sort.Strings(closed)
+
+	fetched := make([]*models.Order, len(closed))
+	errs := make([]error, len(closed))
+	forEach(ctx, c.REST.concurrency, len(closed), func(ctx context.Context, i int) {
+		resp, err := c.REST.GetOrderContext(ctx, closed[i])
+		switch {
+		case err != nil:
+			errs[i] = fmt.Errorf("order %s: %w", closed[i], err)
+		case resp.Order == nil:
+			errs[i] = fmt.Errorf("order %s: not returned", closed[i])
+		default:
+			fetched[i] = resp.Order
+		}
+	})
+
+	cache.mu.Lock()
+	for _, o := range fetched {
+		if o == nil || cache.reconciling[o.ID] {
+			continue
+		}
+		cur, ok := cache.orders[o.ID]
+		if ok && !orderDiffers(cur, *o) {
+			continue
+		}
+		kind := CorrectionOrderUpdated
+		if o.State.IsTerminal() {
+			kind = CorrectionOrderClosed
+		}
+		var before *models.Order
+		if ok {
+			before = &cur
+		}
+		corrections = append(corrections, orderCorrection(kind, before, *o))
+		cache.store(*o)
+	}
+	cache.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return corrections, errors.Join(errs...)
+}
+
+// reconcilePositions syncs Positions if a recent trade is newer than the
+// cached position in its market and reports the markets that changed.
+func (c *Client) reconcilePositions(ctx context.Context) ([]Correction, error) {
+	resp, err := c.REST.GetActivitiesContext(ctx, "", []string{models.ActivityTypeTrade}, reconcileActivityLimit, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activities: %w", err)
+	}
+
+	before := c.Positions.All()
+	stale := make(map[string]bool)
+	for _, a := range resp.Activities {
+		if t := a.Trade; t != nil && t.MarketSlug != "" && tradedSince(t, before[t.MarketSlug]) {
+			stale[t.MarketSlug] = true
+		}
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+
+	if err := c.Positions.Sync(ctx); err != nil {
+		return nil, err
+	}
+	after := c.Positions.All()
+
+	slugs := make([]string, 0, len(stale))
+	for slug := range stale {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	var corrections []Correction
+	for _, slug := range slugs {
+		b, hadBefore := before[slug]
+		a, hasAfter := after[slug]
+		if hadBefore == hasAfter && b.NetPosition == a.NetPosition {
+			continue
+		}
+		corr := Correction{Kind: CorrectionPositionSynced, MarketSlug: slug}
+		if hadBefore {
+			corr.BeforePosition = &b
+		}
+		if hasAfter {
+			corr.AfterPosition = &a
+		}
+		corrections = append(corrections, corr)
+	}
+	return corrections, nil
+}
+
+// tradedSince reports whether t may postdate the cached position p. Missing
+// or unparseable times count as newer, so a gap is never missed.
+func tradedSince(t *models.Trade, p models.UserPosition) bool {
+	if p.UpdateTime == "" {
+		return true
+	}
+	updated, err := p.UpdateTimeParsed()
+	if err != nil {
+		return true
+	}
+	traded, err := t.CreateTimeParsed()
+	if err != nil {
+		return true
+	}
+	return traded.After(updated)
+}
+
+// orderDiffers reports whether the server's copy of an order changes what
+// the cache knows about its progress.
+func orderDiffers(cached, server models.Order) bool {
+	return cached.State != server.State ||
+		cached.CumQuantity != server.CumQuantity ||
+		cached.LeavesQuantity != server.LeavesQuantity
+}
+
+// orderCorrection builds an order correction; before may be nil.
+func orderCorrection(kind CorrectionKind, before *models.Order, after models.Order) Correction {
+	return Correction{
+		Kind:       kind,
+		MarketSlug: after.MarketSlug,
+		OrderID:    after.ID,
+		Before:     before,
+		After:      &after,
+	}
+}
@@ -0,0 +1,342 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// Reconnect backoff bounds.
+const (
+	reconnectBaseDelay = time.Second
+	reconnectMaxDelay  = 30 * time.Second
+
+	// minStableConnection is how long a connection must stay up for the
+	// backoff to reset. A stream that keeps dropping right after connecting
+	// (e.g. the conn is accepted and then immediately reset) continues to
+	// back off instead of reconnecting every reconnectBaseDelay.
+	minStableConnection = 10 * time.Second
+)
+
+// reconnect re-dials a dropped stream with exponential backoff until it
+// succeeds, the client is closed, or maxReconnects dials have failed, then
+// replays the stream's subscriptions. The dropped connection is closed once
+// the new one replaces it.
+// The backoff carries over across connections that drop before
+// minStableConnection, so a flapping stream never reconnects in a tight loop.
+// Doc: api-reference/websocket/overview.mdx - Connection
+func (c *WSClient) reconnect(private bool) {
+	stream := StreamMarkets
+	if private {
+		stream = StreamPrivate
+	}
+
+	c.mu.Lock()
+	flaps := c.flaps[private]
+	c.mu.Unlock()
+
+	delay := reconnectBaseDelay
+	for i := 0; i < flaps && delay < reconnectMaxDelay; i++ {
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(delay)
+		select {
+		case <-c.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		conn, err := c.dialUntilClosed(private)
+		if err != nil {
+			log.Printf("[WS] Reconnect attempt %d to %s WebSocket failed: %v", attempt, stream, err)
+			if c.maxReconnects > 0 && attempt >= c.maxReconnects {
+				c.abandonReconnect(private, attempt, err)
+				return
+			}
+			delay = min(delay*2, reconnectMaxDelay)
+			continue
+		}
+
+		c.mu.Lock()
+		select {
+		case <-c.done:
+			c.mu.Unlock()
+			conn.Close()
+			return
+		default:
+		}
+		old := c.marketsConn
+		if private {
+			old = c.privateConn
+			c.privateConn = conn
+		} else {
+			c.marketsConn = conn
+		}
+		c.setStatus(private, StreamConnected)
+		c.armLifetime(private)
+		c.mu.Unlock()
+
+		if old != nil {
+			old.Close()
+		}
+
+		log.Printf("[WS] Reconnected to %s WebSocket after %d attempt(s)", stream, attempt)
+		c.emit(ConnectionEvent{Type: ConnectionEventConnected, Stream: stream})
+
+		if private {
+			go c.readPrivate(conn)
+		} else {
+			go c.readMarkets(conn)
+		}
+		c.resubscribe(private)
+		return
+	}
+}
+
+// abandonReconnect gives up on a stream after maxReconnects failed dials. The
+// stream is left closed, a terminal ConnectionEventReconnectFailed is
+// emitted, and Consume returns ErrConnectionClosed so the application can
+// alert or exit.
+func (c *WSClient) abandonReconnect(private bool, attempts int, err error) {
+	stream := streamName(private)
+	c.mu.Lock()
+	c.setStatus(private, StreamClosed)
+	c.mu.Unlock()
+
+	log.Printf("[WS] Giving up on %s WebSocket after %d failed reconnect attempt(s)", stream, attempts)
+	c.emit(ConnectionEvent{Type: ConnectionEventReconnectFailed, Stream: stream, Err: err})
+	c.disconnectOnce.Do(func() {
+		close(c.disconnected)
+	})
+}
+
+// armLifetime schedules the stream's connection to be recycled once it
+// reaches the maximum lifetime. Callers must hold c.mu.
+func (c *WSClient) armLifetime(private bool) {
+	if c.maxLifetime <= 0 {
+		return
+	}
+	if old := c.lifetimeTimers[private]; old != nil {
+		old.Stop()
+	}
+	c.lifetimeTimers[private] = time.AfterFunc(c.maxLifetime, func() {
+		c.recycle(private)
+	})
+}
+
+// recycle replaces a stream's connection with a freshly dialed and freshly
+// signed one before the server drops it, then replays its subscriptions.
+// The new connection is established before the old one is closed, so the
+// gap is limited to the resubscribe round trip; snapshots are re-sent, as
+// after any reconnect. If the dial fails, the old connection is kept and
+// the next attempt is scheduled after reconnectMaxDelay.
+// Doc: api/authentication.mdx - Timestamp Validation
+func (c *WSClient) recycle(private bool) {
+	err := c.replaceConnection(private, "connection lifetime reached")
+	if err == nil || errors.Is(err, errClientClosed) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	log.Printf("[WS] Failed to recycle %s WebSocket, keeping current connection: %v", streamName(private), err)
+	c.lifetimeTimers[private] = time.AfterFunc(reconnectMaxDelay, func() {
+		c.recycle(private)
+	})
+}
+
+// dialUntilClosed dials one stream in the background, aborting the dial if
+// the client is closed meanwhile.
+func (c *WSClient) dialUntilClosed(private bool) (*websocket.Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return c.dial(ctx, private)
+}
+
+// errClientClosed is returned by replaceConnection when the client was
+// closed during the dial.
+var errClientClosed = errors.New("websocket client closed")
+
+// replaceConnection dials a new connection for one stream, swaps it in, and
+// closes the old one with reason as the close text, then replays the
+// stream's subscriptions. The old connection is kept if the dial fails.
+func (c *WSClient) replaceConnection(private bool, reason string) error {
+	stream := streamName(private)
+
+	conn, err := c.dialUntilClosed(private)
+
+	c.mu.Lock()
+	select {
+	case <-c.done:
+		c.mu.Unlock()
+		if conn != nil {
+			conn.Close()
+		}
+		return errClientClosed
+	default:
+	}
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+
+	old := c.marketsConn
+	if private {
+		old = c.privateConn
+		c.privateConn = conn
+	} else {
+		c.marketsConn = conn
+	}
+	c.setStatus(private, StreamConnected)
+	c.armLifetime(private)
+	c.mu.Unlock()
+
+	if old != nil {
+		old.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason),
+			time.Now().Add(time.Second))
+		old.Close()
+	}
+
+	log.Printf("[WS] Replaced %s WebSocket connection: %s", stream, reason)
+	c.emit(ConnectionEvent{Type: ConnectionEventConnected, Stream: stream})
+
+	if private {
+		go c.readPrivate(conn)
+	} else {
+		go c.readMarkets(conn)
+	}
+	c.resubscribe(private)
+	return nil
+}
+
+// streamName returns the ConnectionEvent stream name for a stream.
+func streamName(private bool) string {
+	if private {
+		return StreamPrivate
+	}
+	return StreamMarkets
+}
+
+// resubscribe replays every subscription registered on one stream, reusing
+// its request ID so consumers' bookkeeping stays valid. Replays are pending
+// again until acknowledged; AwaitSubscription waits on the new outcome.
+// Subscriptions disabled by a rejected replay are skipped, so a bad slug
+// cannot turn every reconnect into another rejection.
+func (c *WSClient) resubscribe(private bool) {
+	c.mu.Lock()
+	var replay []*subscription
+	for id, sub := range c.subscriptions {
+		if sub.private != private || sub.disabled {
+			continue
+		}
+		c.prepareReplay(id, sub)
+		replay = append(replay, sub)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range replay {
+		c.sendSubscription(sub)
+	}
+	if len(replay) > 0 {
+		log.Printf("[WS] Replayed %d subscription(s) after reconnect", len(replay))
+	}
+}
+
+// Resubscribe re-enables a subscription disabled after the server rejected
+// its replay (see SubscriptionError) and sends it again under its original
+// request ID. Call AwaitSubscription to wait for the outcome; a further
+// rejection disables it again.
+// Doc: api-reference/websocket/overview.mdx - Subscribing
+func (c *WSClient) Resubscribe(requestID string) error {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[requestID]
+	if !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("unknown subscription %q", requestID)
+	}
+	if !sub.disabled {
+		c.mu.Unlock()
+		return fmt.Errorf("subscription %q is not disabled", requestID)
+	}
+	sub.disabled = false
+	c.prepareReplay(requestID, sub)
+	c.mu.Unlock()
+
+	c.sendSubscription(sub)
+	log.Printf("[WS] Resubscribed %s", requestID)
+	return nil
+}
+
+// prepareReplay marks sub as pending again under its original request ID,
+// ready to be sent. Callers must hold c.mu.
+func (c *WSClient) prepareReplay(requestID string, sub *subscription) {
+	delete(c.aliases, sub.wireID)
+	sub.wireID = requestID
+	sub.replay = true
+	sub.replayed = true
+	if sub.acked || sub.err != nil {
+		sub.acked = false
+		sub.err = nil
+		sub.timedOut = false
+		sub.settled = make(chan struct{})
+	}
+	sub.timer.Reset(c.subscribeTimeout)
+}
+
+// sendSubscription sends sub's subscribe request under its current wire ID.
+// Send failures are logged; the subscription then expires at its timeout.
+func (c *WSClient) sendSubscription(sub *subscription) {
+	c.mu.Lock()
+	req := *sub.request
+	req.RequestID = sub.wireID
+	if sub.allMarkets {
+		req.MarketSlugs = nil
+	}
+	c.mu.Unlock()
+
+	msg := &models.WSSubscribeRequest{Subscribe: &req}
+	var err error
+	if sub.private {
+		err = c.sendPrivate(msg)
+	} else {
+		err = c.sendMarkets(msg)
+	}
+	if err != nil {
+		log.Printf("[WS] Failed to resubscribe %s: %v", sub.request.RequestID, err)
+	}
+}
+
+// originalRequestID maps a request ID seen on the wire back to the ID the
+// subscription was created with.
+func (c *WSClient) originalRequestID(wireID string) string {
+	if wireID == "" {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if id, ok := c.aliases[wireID]; ok {
+		return id
+	}
+	return wireID
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// resolutionPollLimit is the number of recent resolution activities Poll
+// fetches per check.
+const resolutionPollLimit = 50
+
+// ResolutionWatcher notifies when a market the user holds resolves and the
+// position is settled. Resolutions are detected on the position stream, by
+// passing Observe as Handlers.OnPositionUpdate, or by polling activities with
+// Poll when the private stream is not used. Each resolution is reported once
+// per watcher, with the market's settlement value looked up over REST.
+//
+//	watcher := client.NewResolutionWatcher(c.REST, func(r *models.PositionResolved) {
+//		log.Println(r.Summary())
+//	})
+//	handlers := &client.Handlers{OnPositionUpdate: watcher.Observe}
+//
+// Doc: api-reference/websocket/private.mdx - Position Update Response
+type ResolutionWatcher struct {
+	rest       *RestClient
+	onResolved func(*models.PositionResolved)
+
+	mu          sync.Mutex
+	settlements map[string]*big.Rat
+	seen        map[string]bool
+}
+
+// NewResolutionWatcher creates a watcher that reports resolutions to
+// onResolved. onResolved runs on its own goroutine, after the settlement
+// lookup.
+func NewResolutionWatcher(rest *RestClient, onResolved func(*models.PositionResolved)) *ResolutionWatcher {
+	return &ResolutionWatcher{
+		rest:        rest,
+		onResolved:  onResolved,
+		settlements: make(map[string]*big.Rat),
+		seen:        make(map[string]bool),
+	}
+}
+
+// Observe reports u if it is a resolution entry. Other position updates are
+// ignored.
+func (w *ResolutionWatcher) Observe(u *models.PositionUpdate) {
+	r, ok := models.PositionResolvedFromUpdate(u, nil)
+	if !ok {
+		return
+	}
+	w.report(r)
+}
+
+// Poll checks recent position resolution activities every interval until ctx
+// ends, reporting those not seen before. Resolutions that happened before
+// the first check are not reported. Failed checks are retried at the next
+// interval.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities
+func (w *ResolutionWatcher) Poll(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	baseline := true
+	for {
+		resp, err := w.rest.GetActivitiesContext(ctx, "",
+			[]string{models.ActivityTypePositionResolution}, resolutionPollLimit, "", "")
+		if err == nil {
+			for _, a := range resp.Activities {
+				if a.PositionResolution == nil {
+					continue
+				}
+				r := models.NewPositionResolved(a.PositionResolution, nil)
+				if baseline {
+					w.markSeen(r)
+					continue
+				}
+				w.report(r)
+			}
+			baseline = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// report fills in the settlement value and calls onResolved, unless r was
+// already reported.
+func (w *ResolutionWatcher) report(r *models.PositionResolved) {
+	if !w.markSeen(r) || w.onResolved == nil {
+		return
+	}
+	go func() {
+		r.Settlement = w.settlement(r.MarketSlug)
+		w.onResolved(r)
+	}()
+}
+
+// markSeen records r, reporting whether it was new. The stream and the
+// activity history describe the same resolution with the same market and
+// update time.
+func (w *ResolutionWatcher) markSeen(r *models.PositionResolved) bool {
+	key := r.MarketSlug + "|" + r.UpdateTime
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen[key] {
+		return false
+	}
+	w.seen[key] = true
+	return true
+}
+
+// settlement returns the market's settlement value, or nil if it cannot be
+// fetched. Successful lookups are cached; settlements do not change.
+func (w *ResolutionWatcher) settlement(slug string) *big.Rat {
+	if slug == "" {
+		return nil
+	}
+	w.mu.Lock()
+	s, ok := w.settlements[slug]
+	w.mu.Unlock()
+	if ok {
+		return s
+	}
+
+	resp, err := w.rest.GetMarketSettlement(slug)
+	if err != nil {
+		log.Printf("[WS] Failed to fetch settlement for %s: %v", slug, err)
+		return nil
+	}
+	s, err = resp.SettlementRat()
+	if err != nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	w.settlements[slug] = s
+	w.mu.Unlock()
+	return s
+}
@@ -6,6 +6,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/polymarket/retail-sample-client-go/auth"
@@ -20,83 +22,297 @@ import (
 	"github.com/polymarket/retail-sample-client-go/models"
 )
 
+// Retry defaults for idempotent requests.
+const (
+	// defaultMaxRetries is the number of retries after the first attempt.
+	defaultMaxRetries = 3
+
+	// defaultRetryBackoff is the delay before the first retry; it doubles on
+	// each subsequent attempt.
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
 // RestClient is an HTTP client for the Polymarket REST API.
 type RestClient struct {
-	config     *config.Config
-	httpClient *http.Client
+	configMu     sync.RWMutex // guards config, replaced by UpdateCredentials
+	config       *config.Config
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	marketCheck  bool
+	responseHook func(ResponseInfo)
+	concurrency  int // fan-out limit, see forEach
+	validate     bool
+	strict       bool // reject unknown response fields
+
+	tradableMu sync.Mutex
+	tradableAt map[string]time.Time // when each market was last seen tradable
+
+	previewRounding bool
+	markets         *MarketCache // tick sizes for previewRounding
+
+	rateMu   sync.Mutex
+	rateInfo RateLimitStatus
 }
 
-// NewRestClient creates a new REST API client.
-func NewRestClient(cfg *config.Config) *RestClient {
-	transport := &http.Transport{}
+// defaultRequestTimeout bounds each REST attempt.
+const defaultRequestTimeout = 30 * time.Second
 
-	// Configure TLS for staging/development with self-signed certs
-	if cfg.InsecureSkipVerify {
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
+// NewRestClient creates a new REST API client. Options override the
+// defaults for the HTTP client, timeouts, and retries.
+func NewRestClient(cfg *config.Config, opts ...ClientOption) *RestClient {
+	o := applyOptions(opts)
+
+	c := &RestClient{
+		config:       cfg,
+		httpClient:   o.httpClient,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+		marketCheck:  o.marketCheck != nil && *o.marketCheck,
+		tradableAt:   make(map[string]time.Time),
+		responseHook: o.responseHook,
+		concurrency:  defaultConcurrency,
+		validate:     o.validateSchema,
+		strict:       o.strictDecode,
+
+		previewRounding: o.previewRounding,
+	}
+	c.markets = NewMarketCache(c)
+	if o.concurrency > 0 {
+		c.concurrency = o.concurrency
+	}
+	if o.maxRetries != nil && *o.maxRetries >= 0 {
+		c.maxRetries = *o.maxRetries
+	}
+	if o.retryBackoff > 0 {
+		c.retryBackoff = o.retryBackoff
 	}
 
-	return &RestClient{
-		config: cfg,
-		httpClient: &http.Client{
-			Timeout:   30 * time.Second,
+	if c.httpClient == nil {
+		// Honor HTTP_PROXY/HTTPS_PROXY/NO_PROXY like http.DefaultTransport does
+		transport := &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		}
+
+		// Configure TLS for staging/development with self-signed certs
+		if cfg.InsecureSkipVerify {
+			transport.TLSClientConfig = &tls.Config{
+				InsecureSkipVerify: true,
+			}
+		}
+
+		timeout := defaultRequestTimeout
+		if o.requestTimeout > 0 {
+			timeout = o.requestTimeout
+		}
+		c.httpClient = &http.Client{
+			Timeout:   timeout,
 			Transport: transport,
-		},
+		}
 	}
+
+	return c
 }
 
-// doRequest performs an authenticated HTTP request.
+// doRequest performs an authenticated HTTP request without a caller deadline.
 func (c *RestClient) doRequest(method, path string, body interface{}) ([]byte, error) {
+	return c.doRequestContext(context.Background(), method, path, body)
+}
+
+// doRequestContext performs an authenticated HTTP request bounded by ctx.
+// path is relative to the API version prefix (e.g. "/markets" for
+// GET /v1/markets); the prefix is applied here so the signed path always
+// matches the request path.
+//
+// GET requests are retried with exponential backoff on transport errors and
+// retryable status codes. The retry loop never sleeps past the context
+// deadline: if the next backoff would exceed it, the last error is returned
+// wrapped with context.DeadlineExceeded. Non-idempotent requests (e.g. order
+// placement) are attempted exactly once. Each attempt is signed afresh (see
+// attempt), so a retry after a long backoff never reuses a timestamp that
+// has fallen outside the server's window.
+//
+// Without credentials (see config.LoadPublic), public market paths are sent
+// unsigned and every other path fails with auth.ErrNoCredentials.
+func (c *RestClient) doRequestContext(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
 	// Build URL
-	reqURL := c.config.BaseURL + path
+	cfg := c.currentConfig()
+	reqURL := cfg.BaseURL + cfg.APIPath(path)
+	if !cfg.HasCredentials() && !isPublicPath(path) {
+		return nil, fmt.Errorf("%s %s requires authentication: %w", method, path, auth.ErrNoCredentials)
+	}
+
+	if v, ok := body.(models.SchemaValidator); ok && c.validate {
+		if err := v.ValidateSchema(); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, path, err)
+		}
+	}
 
 	// Prepare body if provided
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.maxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := c.retryBackoff << (attempt - 1)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return nil, fmt.Errorf("%w: giving up after %d attempt(s): %w",
+					context.DeadlineExceeded, attempt, lastErr)
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("%w: giving up after %d attempt(s): %w", ctx.Err(), attempt, lastErr)
+			case <-timer.C:
+			}
+		}
+
+		respBody, retryable, err := c.attempt(ctx, method, reqURL, bodyBytes)
+		if err == nil {
+			return respBody, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: giving up after %d attempt(s): %w", ctx.Err(), attempt+1, lastErr)
+		}
+		if !retryable {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// attempt performs a single signed HTTP round trip. It reports whether a
+// failure is transient and therefore worth retrying. The request and its
+// signature are built here, per attempt, rather than once per call: the
+// signed timestamp must be within ±5 minutes of the server's clock, and
+// the retries' backoff alone can exceed that.
+// Doc: api/authentication.mdx - Timestamp Validation
+func (c *RestClient) attempt(ctx context.Context, method, reqURL string, bodyBytes []byte) ([]byte, bool, error) {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
 	// Create request
-	req, err := http.NewRequest(method, reqURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set content type for POST requests
-	if body != nil {
+	if bodyBytes != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 
 	// Sign the request
 	// Doc: api/authentication.mdx - Required Headers
-	if err := auth.SignRequest(req, c.config); err != nil {
-		return nil, fmt.Errorf("failed to sign request: %w", err)
+	if cfg := c.currentConfig(); cfg.HasCredentials() {
+		if err := auth.SignRequest(req, cfg); err != nil {
+			return nil, false, fmt.Errorf("failed to sign request: %w", err)
+		}
 	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, true, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if c.responseHook != nil {
+		c.responseHook(ResponseInfo{
+			Method:     method,
+			Path:       req.URL.Path,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			RequestID:  requestIDFromHeader(resp.Header),
+		})
+	}
+
 	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, true, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.noteRateLimited()
 	}
 
 	// Check for errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.RequestID = requestIDFromHeader(resp.Header)
+		return nil, isRetryableStatus(resp.StatusCode), apiErr
 	}
 
-	return respBody, nil
+	return respBody, false, nil
+}
+
+// isPublicPath reports whether a version-relative path is a market data
+// endpoint that can be called without credentials.
+// Note: which endpoints accept unauthenticated requests is not documented;
+// the server rejects any it does not allow.
+func isPublicPath(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	return path == "/markets" || strings.HasPrefix(path, "/markets/") || strings.HasPrefix(path, "/market/")
+}
+
+// decodeResponse decodes a successful response body into v. An empty body
+// (e.g. 204 No Content) leaves v at its zero value instead of failing. With
+// WithStrictDecoding, fields v does not model fail the decode.
+func (c *RestClient) decodeResponse(body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return decodeJSON(body, v, c.strict)
+}
+
+// decodeJSON unmarshals data into v, rejecting unknown fields when strict.
+// Note: types with their own UnmarshalJSON (e.g. models.Amount) decode
+// their contents leniently either way.
+func decodeJSON(data []byte, v interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("strict decode: %w", err)
+	}
+	return nil
+}
+
+// isRetryableStatus reports whether an HTTP status indicates a transient
+// server-side condition.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
 }
 
 // ========== Markets API ==========
@@ -105,46 +321,96 @@ func (c *RestClient) doRequest(method, path string, body interface{}) ([]byte, e
 // GetMarkets retrieves a list of markets with optional filters.
 // Doc: api-reference/market/overview.mdx - GET /v1/markets
 func (c *RestClient) GetMarkets(limit int, active *bool) (*models.GetMarketsResponse, error) {
-	// Build query parameters
-	// Doc: api-reference/market/overview.mdx - Filtering Markets
-	params := url.Values{}
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
-	}
-	if active != nil {
-		params.Set("active", fmt.Sprintf("%t", *active))
-	}
+	return c.QueryMarkets(MarketsQuery{Limit: limit, Active: active})
+}
 
-	path := "/v1/markets"
-	if len(params) > 0 {
-		path += "?" + params.Encode()
-	}
+// MarketsQuery holds the filters and paging for QueryMarkets. Zero values
+// are omitted from the request.
+// Doc: api-reference/market/overview.mdx - Filtering Markets, Pagination & Ordering
+type MarketsQuery struct {
+	Limit     int
+	Offset    int
+	Active    *bool
+	EventSlug string // Note: inferred from MarketMetadata.eventSlug; not listed among documented filters
+
+	// Fields asks the server to return only these market fields, by JSON
+	// name (e.g. "slug", "bestBid"). See models.MarketQuoteFields.
+	// Note: field selection is not documented; a server that ignores it
+	// returns full markets, which decode the same way.
+	Fields []string
+}
 
-	respBody, err := c.doRequest("GET", path, nil)
+// QueryMarkets retrieves one page of markets matching q.
+// Doc: api-reference/market/overview.mdx - GET /v1/markets
+func (c *RestClient) QueryMarkets(q MarketsQuery) (*models.GetMarketsResponse, error) {
+	return c.QueryMarketsContext(context.Background(), q)
+}
+
+// QueryMarketsContext is QueryMarkets bounded by ctx.
+func (c *RestClient) QueryMarketsContext(ctx context.Context, q MarketsQuery) (*models.GetMarketsResponse, error) {
+	respBody, err := c.doRequestContext(ctx, "GET", marketsPath(q), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetMarketsResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	return &result, nil
 }
 
+// marketsPath builds the GET /markets path with q's query parameters.
+func marketsPath(q MarketsQuery) string {
+	// Build query parameters
+	// Doc: api-reference/market/overview.mdx - Filtering Markets
+	params := url.Values{}
+	if q.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", q.Limit))
+	}
+	if q.Offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", q.Offset))
+	}
+	if q.Active != nil {
+		params.Set("active", fmt.Sprintf("%t", *q.Active))
+	}
+	if q.EventSlug != "" {
+		params.Set("eventSlug", q.EventSlug)
+	}
+	if len(q.Fields) > 0 {
+		params.Set("fields", strings.Join(q.Fields, ","))
+	}
+
+	path := "/markets"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return path
+}
+
 // GetMarketBySlug retrieves a market by its slug.
 // Doc: api-reference/market/overview.mdx - GET /v1/market/slug/{slug}
 func (c *RestClient) GetMarketBySlug(slug string) (*models.Market, error) {
-	path := "/v1/market/slug/" + url.PathEscape(slug)
+	return c.GetMarketBySlugContext(context.Background(), slug)
+}
 
-	respBody, err := c.doRequest("GET", path, nil)
+// GetMarketBySlugContext is GetMarketBySlug bounded by ctx.
+func (c *RestClient) GetMarketBySlugContext(ctx context.Context, slug string) (*models.Market, error) {
+	slug, err := validateSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/market/slug/" + url.PathEscape(slug)
+
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.Market
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -154,34 +420,88 @@ func (c *RestClient) GetMarketBySlug(slug string) (*models.Market, error) {
 // GetMarketSettlement retrieves settlement data for a resolved market.
 // Doc: api-reference/market/overview.mdx - Settlement
 func (c *RestClient) GetMarketSettlement(slug string) (*models.MarketSettlement, error) {
-	path := "/v1/markets/" + url.PathEscape(slug) + "/settlement"
+	return c.GetMarketSettlementContext(context.Background(), slug)
+}
 
-	respBody, err := c.doRequest("GET", path, nil)
+// GetMarketSettlementContext is GetMarketSettlement bounded by ctx.
+func (c *RestClient) GetMarketSettlementContext(ctx context.Context, slug string) (*models.MarketSettlement, error) {
+	slug, err := validateSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/markets/" + url.PathEscape(slug) + "/settlement"
+
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.MarketSettlement
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	return &result, nil
 }
 
+// GetOrderBook retrieves a one-time order book snapshot for a market, without
+// opening a WebSocket. depth limits each side to the top N levels; zero
+// returns the full book.
+//
+// Note: not documented. The path GET /v1/markets/{slug}/book and its
+// {"marketData": ...} envelope are assumed to mirror the markets stream's
+// market data message; the depth is applied locally.
+// Doc: api-reference/websocket/markets.mdx - Market Data Response
+func (c *RestClient) GetOrderBook(slug string, depth int) (*models.OrderBook, error) {
+	return c.GetOrderBookContext(context.Background(), slug, depth)
+}
+
+// GetOrderBookContext is GetOrderBook bounded by ctx.
+func (c *RestClient) GetOrderBookContext(ctx context.Context, slug string, depth int) (*models.OrderBook, error) {
+	slug, err := validateSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/markets/" + url.PathEscape(slug) + "/book"
+
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.GetOrderBookResponse
+	if err := c.decodeResponse(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.MarketData == nil {
+		return nil, fmt.Errorf("order book response for %s has no marketData", slug)
+	}
+
+	book := models.NewOrderBook(result.MarketData)
+	book.Truncate(depth)
+	return book, nil
+}
+
 // ========== Account API ==========
 // Doc: api-reference/account/overview.mdx
 
 // GetBalances retrieves account balances.
 // Doc: api-reference/account/overview.mdx - GET /v1/account/balances
 func (c *RestClient) GetBalances() (*models.GetBalancesResponse, error) {
-	respBody, err := c.doRequest("GET", "/v1/account/balances", nil)
+	return c.GetBalancesContext(context.Background())
+}
+
+// GetBalancesContext is GetBalances bounded by ctx.
+func (c *RestClient) GetBalancesContext(ctx context.Context) (*models.GetBalancesResponse, error) {
+	respBody, err := c.doRequestContext(ctx, "GET", "/account/balances", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetBalancesResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -194,6 +514,11 @@ func (c *RestClient) GetBalances() (*models.GetBalancesResponse, error) {
 // GetPositions retrieves trading positions.
 // Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions
 func (c *RestClient) GetPositions(market string, limit int, cursor string) (*models.GetPositionsResponse, error) {
+	return c.GetPositionsContext(context.Background(), market, limit, cursor)
+}
+
+// GetPositionsContext is GetPositions bounded by ctx.
+func (c *RestClient) GetPositionsContext(ctx context.Context, market string, limit int, cursor string) (*models.GetPositionsResponse, error) {
 	params := url.Values{}
 	if market != "" {
 		params.Set("market", market)
@@ -205,18 +530,18 @@ func (c *RestClient) GetPositions(market string, limit int, cursor string) (*mod
 		params.Set("cursor", cursor)
 	}
 
-	path := "/v1/portfolio/positions"
+	path := "/portfolio/positions"
 	if len(params) > 0 {
 		path += "?" + params.Encode()
 	}
 
-	respBody, err := c.doRequest("GET", path, nil)
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetPositionsResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -226,6 +551,11 @@ func (c *RestClient) GetPositions(market string, limit int, cursor string) (*mod
 // GetActivities retrieves trading activity history.
 // Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities
 func (c *RestClient) GetActivities(marketSlug string, types []string, limit int, cursor string, sortOrder string) (*models.GetActivitiesResponse, error) {
+	return c.GetActivitiesContext(context.Background(), marketSlug, types, limit, cursor, sortOrder)
+}
+
+// GetActivitiesContext is GetActivities bounded by ctx.
+func (c *RestClient) GetActivitiesContext(ctx context.Context, marketSlug string, types []string, limit int, cursor string, sortOrder string) (*models.GetActivitiesResponse, error) {
 	params := url.Values{}
 	if marketSlug != "" {
 		params.Set("marketSlug", marketSlug)
@@ -243,18 +573,18 @@ func (c *RestClient) GetActivities(marketSlug string, types []string, limit int,
 		params.Set("sortOrder", sortOrder)
 	}
 
-	path := "/v1/portfolio/activities"
+	path := "/portfolio/activities"
 	if len(params) > 0 {
 		path += "?" + params.Encode()
 	}
 
-	respBody, err := c.doRequest("GET", path, nil)
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetActivitiesResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -265,41 +595,106 @@ func (c *RestClient) GetActivities(marketSlug string, types []string, limit int,
 // Doc: api-reference/orders/overview.mdx
 // Schema: api-reference/oapi-schemas/orders-schema.json
 
-// CreateOrder creates a new order.
+// CreateOrder creates a new order. With WithMarketCheck, orders on a market
+// that is closed, archived, or inactive fail with *MarketNotTradableError
+// before being sent. Server rejections whose error code reports the same
+// are returned as *MarketNotTradableError whether or not the check is on. A
+// server rejection as a duplicate is returned as *DuplicateOrderError, and
+// one for breaching a risk limit as *RiskLimitError. If the response reports
+// the order as immediately rejected (synchronous execution), the response is
+// returned together with an *OrderRejectedError, wrapped in a
+// *RiskLimitError when the rejection is for a risk limit.
 // Doc: api-reference/orders/overview.mdx - POST /v1/orders
 // Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest
 func (c *RestClient) CreateOrder(req *models.CreateOrderRequest) (*models.CreateOrderResponse, error) {
-	respBody, err := c.doRequest("POST", "/v1/orders", req)
+	return c.CreateOrderContext(context.Background(), req)
+}
+
+// CreateOrderContext is CreateOrder bounded by ctx, for submissions that
+// need a tighter deadline than the client's request timeout. It returns as
+// soon as ctx ends, with an error wrapping ctx.Err(). If ctx had already
+// ended before the order was sent, the error says "order not sent".
+//
+// Note: a context that ends after the request was sent does not withdraw
+// it; the order may still be placed server-side. Orders carry no
+// idempotency key, so the outcome cannot be looked up by request and a
+// blind resubmission may place a second order. After such an error,
+// check GetOpenOrders for the market (or the order stream) before retrying.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest
+func (c *RestClient) CreateOrderContext(ctx context.Context, req *models.CreateOrderRequest) (*models.CreateOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order: %w", err)
+	}
+	if c.marketCheck {
+		if err := c.guardMarket(ctx, req.MarketSlug); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("order not sent: %w", err)
+	}
+
+	respBody, err := c.doRequestContext(ctx, "POST", "/orders", req)
 	if err != nil {
-		return nil, err
+		if notTradable := asMarketNotTradable(err, req.MarketSlug); notTradable != nil {
+			return nil, notTradable
+		}
+		if riskErr := asRiskLimit(err, req.MarketSlug); riskErr != nil {
+			return nil, riskErr
+		}
+		return nil, asDuplicateOrder(err)
 	}
 
 	var result models.CreateOrderResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &result, nil
+	return &result, rejection(&result)
 }
 
-// PreviewOrder previews an order before submission.
+// PreviewOrder previews an order before submission. With
+// WithPreviewRounding, a limit price is first rounded to the market's tick
+// size (req itself is left unchanged), and the response reports
+// RequestedPrice, RoundedPrice, and a Warning when rounding changed the
+// price or could not be done.
 // Doc: api-reference/orders/overview.mdx - POST /v1/order/preview
 // Schema: api-reference/oapi-schemas/orders-schema.json - PreviewOrderRequest
 func (c *RestClient) PreviewOrder(req *models.CreateOrderRequest) (*models.PreviewOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid order: %w", err)
+	}
+
+	sent := req
+	var rounded *models.Amount
+	var warning string
+	if c.previewRounding && req.Price != nil {
+		rounded, warning = c.roundToTick(req.MarketSlug, req.Price)
+		copied := *req
+		copied.Price = rounded
+		sent = &copied
+	}
+
 	previewReq := &models.PreviewOrderRequest{
-		Request: req,
+		Request: sent,
 	}
 
-	respBody, err := c.doRequest("POST", "/v1/order/preview", previewReq)
+	respBody, err := c.doRequest("POST", "/order/preview", previewReq)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.PreviewOrderResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	if rounded != nil {
+		result.RequestedPrice = req.Price
+		result.RoundedPrice = rounded
+		result.Warning = warning
+	}
 	return &result, nil
 }
 
@@ -307,20 +702,25 @@ func (c *RestClient) PreviewOrder(req *models.CreateOrderRequest) (*models.Previ
 // Doc: api-reference/orders/overview.mdx - GET /v1/orders/open
 // Schema: api-reference/oapi-schemas/orders-schema.json - GetOpenOrdersResponse
 func (c *RestClient) GetOpenOrders(slugs []string) (*models.GetOpenOrdersResponse, error) {
-	path := "/v1/orders/open"
+	return c.GetOpenOrdersContext(context.Background(), slugs)
+}
+
+// GetOpenOrdersContext is GetOpenOrders bounded by ctx.
+func (c *RestClient) GetOpenOrdersContext(ctx context.Context, slugs []string) (*models.GetOpenOrdersResponse, error) {
+	path := "/orders/open"
 	if len(slugs) > 0 {
 		params := url.Values{}
 		params.Set("slugs", strings.Join(slugs, ","))
 		path += "?" + params.Encode()
 	}
 
-	respBody, err := c.doRequest("GET", path, nil)
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetOpenOrdersResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -331,32 +731,47 @@ func (c *RestClient) GetOpenOrders(slugs []string) (*models.GetOpenOrdersRespons
 // Doc: api-reference/orders/overview.mdx - GET /v1/order/{orderId}
 // Schema: api-reference/oapi-schemas/orders-schema.json - GetOrderResponse
 func (c *RestClient) GetOrder(orderID string) (*models.GetOrderResponse, error) {
-	path := "/v1/order/" + url.PathEscape(orderID)
+	return c.GetOrderContext(context.Background(), orderID)
+}
+
+// GetOrderContext is GetOrder bounded by ctx.
+func (c *RestClient) GetOrderContext(ctx context.Context, orderID string) (*models.GetOrderResponse, error) {
+	path := "/order/" + url.PathEscape(orderID)
 
-	respBody, err := c.doRequest("GET", path, nil)
+	respBody, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.GetOrderResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	return &result, nil
 }
 
-// CancelOrder cancels a specific order.
+// CancelOrder cancels a specific order in marketSlug, which must not be
+// empty. The response body, whether empty (204 No Content) or JSON, is not
+// used.
 // Doc: api-reference/orders/overview.mdx - POST /v1/order/{orderId}/cancel
 // Schema: api-reference/oapi-schemas/orders-schema.json - CancelOrderRequest
 func (c *RestClient) CancelOrder(orderID string, marketSlug string) error {
-	path := "/v1/order/" + url.PathEscape(orderID) + "/cancel"
+	if orderID == "" {
+		return fmt.Errorf("order ID is empty")
+	}
+	marketSlug, err := validateSlug(marketSlug)
+	if err != nil {
+		return err
+	}
+
+	path := "/order/" + url.PathEscape(orderID) + "/cancel"
 
 	req := &models.CancelOrderRequest{
 		MarketSlug: marketSlug,
 	}
 
-	_, err := c.doRequest("POST", path, req)
+	_, err = c.doRequest("POST", path, req)
 	return err
 }
 
@@ -368,13 +783,13 @@ func (c *RestClient) CancelAllOpenOrders(slugs []string) (*models.CancelOpenOrde
 		Slugs: slugs,
 	}
 
-	respBody, err := c.doRequest("POST", "/v1/orders/open/cancel", req)
+	respBody, err := c.doRequest("POST", "/orders/open/cancel", req)
 	if err != nil {
 		return nil, err
 	}
 
 	var result models.CancelOpenOrdersResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeResponse(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
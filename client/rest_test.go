@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/config"
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// newTestRestClient returns a RestClient with throwaway credentials that
+// sends every request to handler.
+func newTestRestClient(t *testing.T, handler http.Handler, opts ...ClientOption) *RestClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	_, pk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return NewRestClient(&config.Config{BaseURL: srv.URL, APIKey: "test-key", PrivateKey: pk}, opts...)
+}
+
+func TestCreateOrderContextBoundsMarketCheck(t *testing.T) {
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}), WithMarketCheck(true), WithMaxRetries(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	price := &models.Amount{Value: "0.55", Currency: "USD"}
+	req := models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 10)
+
+	start := time.Now()
+	_, err := c.CreateOrderContext(ctx, req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CreateOrderContext = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("CreateOrderContext returned after %v, want about the 100ms deadline", elapsed)
+	}
+}
+
+func TestRetrySignsEachAttempt(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		timestamps []string
+	)
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		timestamps = append(timestamps, r.Header.Get("X-PM-Timestamp"))
+		first := len(timestamps) == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}), WithMaxRetries(1), WithRetryBackoff(20*time.Millisecond))
+
+	if _, err := c.doRequest(http.MethodGet, "/markets", nil); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(timestamps) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(timestamps))
+	}
+	if timestamps[0] == "" || timestamps[0] == timestamps[1] {
+		t.Errorf("X-PM-Timestamp = %q then %q, want a fresh timestamp on retry", timestamps[0], timestamps[1])
+	}
+}
+
+func TestRetryStopsAtContextDeadline(t *testing.T) {
+	calls := map[string]func(*RestClient, context.Context) error{
+		"GetOpenOrdersContext": func(c *RestClient, ctx context.Context) error {
+			_, err := c.GetOpenOrdersContext(ctx, nil)
+			return err
+		},
+		"GetOrderContext": func(c *RestClient, ctx context.Context) error {
+			_, err := c.GetOrderContext(ctx, "order-1")
+			return err
+		},
+		"GetBalancesContext": func(c *RestClient, ctx context.Context) error {
+			_, err := c.GetBalancesContext(ctx)
+			return err
+		},
+	}
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		backoff time.Duration
+	}{
+		{"backoff longer than deadline", 100 * time.Millisecond, time.Second},
+		{"deadline during backoff", 300 * time.Millisecond, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for method, call := range calls {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}), WithMaxRetries(10), WithRetryBackoff(tt.backoff))
+
+				ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+				defer cancel()
+				start := time.Now()
+				if err := call(c, ctx); !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("%s = %v, want context.DeadlineExceeded", method, err)
+				}
+				if elapsed := time.Since(start); elapsed > tt.timeout+500*time.Millisecond {
+					t.Errorf("returned after %v, want by the %v deadline", elapsed, tt.timeout)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateOrderSynchronousRejection(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		wantRejected bool
+		wantRisk     bool
+	}{
+		{
+			name:     "filled",
+			response: `{"id":"order-1","executions":[{"id":"exec-1","type":"EXECUTION_TYPE_FILL"}]}`,
+		},
+		{
+			name: "rejected",
+			response: `{"id":"order-1","executions":[{"id":"exec-1","type":"EXECUTION_TYPE_REJECTED",` +
+				`"orderRejectReason":"ORDER_REJECT_REASON_INSUFFICIENT_FUNDS","text":"insufficient balance"}]}`,
+			wantRejected: true,
+		},
+		{
+			name: "rejected for risk",
+			response: `{"id":"order-1","executions":[{"id":"exec-1","type":"EXECUTION_TYPE_REJECTED",` +
+				`"text":"exceeds max order notional"}]}`,
+			wantRejected: true,
+			wantRisk:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.response))
+			}), WithMarketCheck(false))
+
+			price := &models.Amount{Value: "0.55", Currency: "USD"}
+			req := models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 10)
+			req.SynchronousExecution = true
+			resp, err := c.CreateOrder(req)
+			if resp == nil || resp.ID != "order-1" {
+				t.Fatalf("CreateOrder response = %+v, want order-1", resp)
+			}
+
+			var rejected *OrderRejectedError
+			if got := errors.As(err, &rejected); got != tt.wantRejected {
+				t.Fatalf("CreateOrder error = %v, want OrderRejectedError: %v", err, tt.wantRejected)
+			}
+			if rejected != nil && rejected.OrderID != "order-1" {
+				t.Errorf("OrderRejectedError.OrderID = %q, want order-1", rejected.OrderID)
+			}
+			var riskErr *RiskLimitError
+			if got := errors.As(err, &riskErr); got != tt.wantRisk {
+				t.Errorf("CreateOrder error = %v, want RiskLimitError: %v", err, tt.wantRisk)
+			}
+		})
+	}
+}
+
+func TestEmptySuccessResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"204 no content", http.StatusNoContent, ""},
+		{"empty 200", http.StatusOK, ""},
+		{"whitespace 200", http.StatusOK, " \n"},
+		{"json 200", http.StatusOK, `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+
+			balances, err := c.GetBalances()
+			if err != nil {
+				t.Fatalf("GetBalances: %v", err)
+			}
+			if len(balances.Balances) != 0 {
+				t.Errorf("GetBalances = %+v, want no balances", balances)
+			}
+			orders, err := c.GetOpenOrders(nil)
+			if err != nil {
+				t.Fatalf("GetOpenOrders: %v", err)
+			}
+			if len(orders.Orders) != 0 {
+				t.Errorf("GetOpenOrders = %+v, want no orders", orders)
+			}
+			if err := c.CancelOrder("order-1", "test-market"); err != nil {
+				t.Errorf("CancelOrder: %v", err)
+			}
+		})
+	}
+}
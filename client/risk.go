@@ -0,0 +1,158 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// RiskLimitError reports that an order was rejected by the server's risk
+// checks, typically for exceeding a per-market or per-account notional or
+// size limit. Limit is set when the server reports the cap it applied. Err
+// is the underlying *APIError or *OrderRejectedError, so errors.As still
+// matches those.
+//
+// Note: the API documents no endpoint for reading risk limits ahead of time
+// and no specific error code for a breach. Rejections are recognized from
+// the reason and message text, and orders that sat in
+// ORDER_STATE_PENDING_RISK before being rejected are reported by OrderCache.
+// Doc: api-reference/orders/overview.mdx - Order States
+type RiskLimitError struct {
+	OrderID    string
+	MarketSlug string
+	Reason     string
+	Limit      *models.Amount
+	Err        error
+}
+
+func (e *RiskLimitError) Error() string {
+	msg := "order rejected by risk check"
+	if e.OrderID != "" {
+		msg = fmt.Sprintf("order %s rejected by risk check", e.OrderID)
+	}
+	if e.MarketSlug != "" {
+		msg += " on " + e.MarketSlug
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Limit != nil {
+		msg += " (limit " + e.Limit.Format(2) + ")"
+	}
+	return msg
+}
+
+func (e *RiskLimitError) Unwrap() error {
+	return e.Err
+}
+
+// riskTerms are phrases in rejection reasons, codes, and messages that
+// indicate a risk limit breach.
+// Note: the API does not document these; they are matched case-insensitively.
+var riskTerms = []string{
+	"risk", "notional", "exposure", "max order", "maximum order",
+	"size limit", "position limit", "order limit",
+}
+
+// isRiskReason reports whether any of texts mentions a risk limit.
+func isRiskReason(texts ...string) bool {
+	for _, s := range texts {
+		lower := strings.ToLower(s)
+		for _, term := range riskTerms {
+			if strings.Contains(lower, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// asRiskLimit converts an API error rejecting an order for breaching a risk
+// limit into a *RiskLimitError, or returns nil.
+func asRiskLimit(err error, slug string) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !isRiskReason(apiErr.Code, apiErr.Message) {
+		return nil
+	}
+	reason := apiErr.Message
+	if reason == "" {
+		reason = apiErr.Code
+	}
+	return &RiskLimitError{
+		MarketSlug: slug,
+		Reason:     reason,
+		Limit:      apiErr.riskLimit(),
+		Err:        apiErr,
+	}
+}
+
+// riskLimit returns the limit reported in the error details, or nil.
+func (e *APIError) riskLimit() *models.Amount {
+	value := e.detailString("limit", "maxNotional", "max_notional", "maxOrderSize", "max_order_size")
+	if value == "" {
+		return nil
+	}
+	if _, err := models.ParseDecimal(value); err != nil {
+		return nil
+	}
+	return &models.Amount{Value: value, Currency: e.detailString("currency")}
+}
+
+// riskRejection builds a *RiskLimitError for a rejection execution of
+// orderID when its reason mentions a risk limit, or when the order was held
+// for a risk check first (pendingRisk). It returns nil otherwise.
+func riskRejection(orderID string, e *models.Execution, pendingRisk bool) *RiskLimitError {
+	if e.Type != models.ExecutionTypeRejected {
+		return nil
+	}
+	if !pendingRisk && !isRiskReason(e.OrderRejectReason, e.Text) {
+		return nil
+	}
+	riskErr := &RiskLimitError{
+		OrderID: orderID,
+		Reason:  e.OrderRejectReason,
+		Err:     &OrderRejectedError{OrderID: orderID, Reason: e.OrderRejectReason, Text: e.Text},
+	}
+	if riskErr.Reason == "" {
+		riskErr.Reason = e.Text
+	}
+	if e.Order != nil {
+		riskErr.MarketSlug = e.Order.MarketSlug
+	}
+	return riskErr
+}
+
+// trackRisk remembers orders held in ORDER_STATE_PENDING_RISK and records a
+// *RiskLimitError when such an order, or one whose rejection reason mentions
+// a risk limit, is rejected.
+func (c *OrderCache) trackRisk(e *models.Execution) {
+	o := e.Order
+	c.mu.Lock()
+	if o.State == models.OrderStatePendingRisk {
+		c.pendingRisk[o.ID] = true
+	}
+	riskErr := riskRejection(o.ID, e, c.pendingRisk[o.ID])
+	if riskErr != nil {
+		c.riskErrs[o.ID] = riskErr
+	}
+	if o.State.IsTerminal() {
+		delete(c.pendingRisk, o.ID)
+	}
+	c.mu.Unlock()
+
+	if riskErr != nil {
+		log.Printf("[WS] %v", riskErr)
+	}
+}
+
+// RiskRejection returns the *RiskLimitError for an order the risk checks
+// rejected, if the rejection was seen on the stream.
+func (c *OrderCache) RiskRejection(orderID string) (*RiskLimitError, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	riskErr, ok := c.riskErrs[orderID]
+	return riskErr, ok
+}
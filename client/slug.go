@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// validateSlug trims surrounding whitespace from a market slug and rejects
+// one that is empty or contains whitespace, which would otherwise produce a
+// malformed path or a subscription that never matches.
+// Note: slug case is left unchanged. Slugs are issued in lowercase, but
+// whether the API matches them case-insensitively is not documented.
+func validateSlug(slug string) (string, error) {
+	trimmed := strings.TrimSpace(slug)
+	if trimmed == "" {
+		return "", fmt.Errorf("market slug is empty")
+	}
+	if strings.IndexFunc(trimmed, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("invalid market slug %q: contains whitespace", slug)
+	}
+	return trimmed, nil
+}
+
+// validateSlugs applies validateSlug to each slug, returning a new slice.
+// An empty list is valid and stays empty.
+func validateSlugs(slugs []string) ([]string, error) {
+	if len(slugs) == 0 {
+		return slugs, nil
+	}
+	out := make([]string, len(slugs))
+	for i, s := range slugs {
+		v, err := validateSlug(s)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
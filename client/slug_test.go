@@ -0,0 +1,45 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestValidateSlug(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		want    string
+		wantErr bool
+	}{
+		{"valid", "test-market", "test-market", false},
+		{"surrounding whitespace", "  test-market\n", "test-market", false},
+		{"case preserved", "Test-Market", "Test-Market", false},
+		{"empty", "", "", true},
+		{"whitespace only", " \t\n", "", true},
+		{"inner whitespace", "test market", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateSlug(tt.slug)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSlug(%q) error = %v, wantErr %v", tt.slug, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validateSlug(%q) = %q, want %q", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugValidatedBeforeRequest(t *testing.T) {
+	c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	if _, err := c.GetMarketBySlug("  "); err == nil {
+		t.Error("GetMarketBySlug with a blank slug succeeded, want an error")
+	}
+	if err := c.CancelOrder("order-1", ""); err == nil {
+		t.Error("CancelOrder with an empty slug succeeded, want an error")
+	}
+}
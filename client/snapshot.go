@@ -0,0 +1,156 @@
+package client
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// RateLimitStatus reports how often the server has throttled REST requests
+// (HTTP 429). Throttled requests are retried; see WithMaxRetries.
+// Note: the API's rate-limit headers are not documented, so only 429
+// responses are counted. Use WithResponseHook to inspect headers.
+type RateLimitStatus struct {
+	Throttled     int       `json:"throttled"`     // 429 responses since the client was created
+	LastThrottled time.Time `json:"lastThrottled"` // Zero if never throttled
+}
+
+// RateLimitStatus returns the client's throttling counters.
+func (c *RestClient) RateLimitStatus() RateLimitStatus {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateInfo
+}
+
+// noteRateLimited counts a 429 response.
+func (c *RestClient) noteRateLimited() {
+	c.rateMu.Lock()
+	c.rateInfo.Throttled++
+	c.rateInfo.LastThrottled = time.Now()
+	c.rateMu.Unlock()
+}
+
+// DebugSnapshot is a point-in-time view of a client's state for bug reports.
+// It holds no credentials or order details, only counts and identifiers.
+type DebugSnapshot struct {
+	TakenAt       time.Time                 `json:"takenAt"`
+	Streams       map[string]StreamSnapshot `json:"streams"` // Keyed by StreamPrivate, StreamMarkets
+	Subscriptions []SubscriptionSnapshot    `json:"subscriptions"`
+	Groups        int                       `json:"groups"`
+	Orders        int                       `json:"orders"`     // Orders in the order cache
+	OpenOrders    int                       `json:"openOrders"` // Cached orders not in a terminal state
+	OrdersSynced  bool                      `json:"ordersSynced"`
+	Balances      int                       `json:"balances"` // Currencies in the balance cache
+	RateLimit     RateLimitStatus           `json:"rateLimit"`
+}
+
+// StreamSnapshot is the state of one WebSocket stream.
+type StreamSnapshot struct {
+	Status        string    `json:"status"`
+	ConnectedAt   time.Time `json:"connectedAt"`
+	LastHeartbeat time.Time `json:"lastHeartbeat"`
+	Flaps         int       `json:"flaps,omitempty"` // Recent connections that dropped quickly
+}
+
+// SubscriptionSnapshot is the state of one registered subscription.
+type SubscriptionSnapshot struct {
+	RequestID   string   `json:"requestId"`
+	WireID      string   `json:"wireId,omitempty"` // Set when replayed under a different ID
+	Type        string   `json:"type"`
+	MarketSlugs []string `json:"marketSlugs,omitempty"`
+	AllMarkets  bool     `json:"allMarkets,omitempty"`
+	State       string   `json:"state"` // "pending", "active", "unacknowledged" (timed out), or "disabled"
+}
+
+// JSON renders the snapshot as indented JSON, ready to attach to a bug
+// report.
+func (s *DebugSnapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// DebugSnapshot captures the state of the connections, subscriptions,
+// caches, and REST throttling.
+func (c *Client) DebugSnapshot() *DebugSnapshot {
+	s := &DebugSnapshot{
+		TakenAt:   time.Now(),
+		RateLimit: c.REST.RateLimitStatus(),
+	}
+	c.WS.fillSnapshot(s)
+
+	c.Orders.mu.Lock()
+	s.Orders = len(c.Orders.orders)
+	for _, o := range c.Orders.orders {
+		if !expireLocally(o, s.TakenAt).State.IsTerminal() {
+			s.OpenOrders++
+		}
+	}
+	c.Orders.mu.Unlock()
+	select {
+	case <-c.Orders.Synced():
+		s.OrdersSynced = true
+	default:
+	}
+
+	c.mu.Lock()
+	s.Balances = len(c.balances)
+	c.mu.Unlock()
+	return s
+}
+
+// fillSnapshot records the stream and subscription state into s.
+func (c *WSClient) fillSnapshot(s *DebugSnapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.Streams = make(map[string]StreamSnapshot, 2)
+	for _, private := range []bool{true, false} {
+		st := StreamSnapshot{
+			Status:        c.status(private).String(),
+			LastHeartbeat: c.heartbeats[private],
+			Flaps:         c.flaps[private],
+		}
+		if c.status(private) == StreamConnected {
+			st.ConnectedAt = c.connectedAt[private]
+		}
+		s.Streams[streamName(private)] = st
+	}
+
+	for id, sub := range c.subscriptions {
+		snap := SubscriptionSnapshot{
+			RequestID:   id,
+			Type:        subscriptionCategory(sub).String(),
+			MarketSlugs: sub.request.MarketSlugs,
+			AllMarkets:  sub.allMarkets,
+			State:       "pending",
+		}
+		if sub.wireID != id {
+			snap.WireID = sub.wireID
+		}
+		switch {
+		case sub.disabled:
+			snap.State = "disabled"
+		case sub.acked:
+			snap.State = "active"
+		case sub.timedOut:
+			snap.State = "unacknowledged"
+		}
+		s.Subscriptions = append(s.Subscriptions, snap)
+	}
+	sort.Slice(s.Subscriptions, func(i, j int) bool {
+		return s.Subscriptions[i].RequestID < s.Subscriptions[j].RequestID
+	})
+	s.Groups = len(c.groups)
+}
+
+// subscriptionCategory maps a subscription's request type back to its
+// category; the integer types overlap between the two streams.
+func subscriptionCategory(sub *subscription) models.SubscriptionCategory {
+	for cat := models.SubscriptionCategoryOrder; cat <= models.SubscriptionCategoryTrade; cat++ {
+		if cat.IsPrivate() == sub.private && cat.RequestType() == sub.request.SubscriptionType {
+			return cat
+		}
+	}
+	return models.SubscriptionCategoryUnknown
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// GetAccountSummary concurrently fetches balances, positions, and open orders
+// and composes them into a single snapshot with computed totals.
+// Doc: api-reference/account/overview.mdx - GET /v1/account/balances
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/positions
+// Doc: api-reference/orders/overview.mdx - GET /v1/orders/open
+//
+// Totals are computed per currency in ByCurrency. When the account holds
+// more than one currency, MultiCurrency is set and the single-currency
+// totals are left zero.
+//
+// Partial failures do not fail the call: the summary contains whatever was
+// fetched successfully and records an error per failed source. The returned
+// error is non-nil only when every source failed.
+func (c *RestClient) GetAccountSummary() (*models.AccountSummary, error) {
+	summary := &models.AccountSummary{}
+
+	fetches := []func(){
+		func() {
+			resp, err := c.GetBalances()
+			if err != nil {
+				summary.BalancesErr = fmt.Errorf("balances: %w", err)
+				return
+			}
+			summary.Balances = resp.Balances
+		},
+		func() {
+			positions, err := c.getAllPositions()
+			if err != nil {
+				summary.PositionsErr = fmt.Errorf("positions: %w", err)
+				return
+			}
+			summary.Positions = positions
+		},
+		func() {
+			resp, err := c.GetOpenOrders(nil)
+			if err != nil {
+				summary.OpenOrdersErr = fmt.Errorf("open orders: %w", err)
+				return
+			}
+			summary.OpenOrders = resp.Orders
+		},
+	}
+	forEach(context.Background(), c.concurrency, len(fetches), func(_ context.Context, i int) {
+		fetches[i]()
+	})
+
+	totals := make(map[string]*models.CurrencyTotals)
+	totalsFor := func(currency string) *models.CurrencyTotals {
+		currency = models.CurrencyOrDefault(currency)
+		t, ok := totals[currency]
+		if !ok {
+			t = &models.CurrencyTotals{Currency: currency}
+			totals[currency] = t
+		}
+		return t
+	}
+	for _, b := range summary.Balances {
+		t := totalsFor(b.Currency)
+		t.TotalBalance += b.CurrentBalance
+		t.BuyingPower += b.BuyingPower
+		t.OpenOrderExposure += b.OpenOrders
+		t.PendingWithdrawals += b.PendingWithdrawalTotal()
+	}
+	for _, p := range summary.Positions {
+		if p.CashValue == nil {
+			continue
+		}
+		if v, err := p.CashValue.Float(); err == nil {
+			totalsFor(p.CashValue.Currency).PositionsValue += v
+		}
+	}
+	summary.ByCurrency = make(map[string]models.CurrencyTotals, len(totals))
+	summary.MultiCurrency = len(totals) > 1
+	for currency, t := range totals {
+		t.TotalEquity = t.TotalBalance + t.PositionsValue
+		summary.ByCurrency[currency] = *t
+		if len(totals) == 1 {
+			summary.TotalBalance = t.TotalBalance
+			summary.BuyingPower = t.BuyingPower
+			summary.OpenOrderExposure = t.OpenOrderExposure
+			summary.PositionsValue = t.PositionsValue
+			summary.TotalEquity = t.TotalEquity
+		}
+	}
+	summary.PositionCount = len(summary.Positions)
+	summary.OpenOrderCount = len(summary.OpenOrders)
+
+	if summary.BalancesErr != nil && summary.PositionsErr != nil && summary.OpenOrdersErr != nil {
+		return summary, fmt.Errorf("failed to fetch account summary: %w", summary.Err())
+	}
+	return summary, nil
+}
+
+// getAllPositions follows the positions cursor until EOF and returns the
+// merged map of market slug to position.
+// Doc: api-reference/portfolio/overview.mdx - Pagination
+func (c *RestClient) getAllPositions() (map[string]models.UserPosition, error) {
+	return c.getAllPositionsContext(context.Background())
+}
+
+// getAllPositionsContext is getAllPositions bounded by ctx.
+func (c *RestClient) getAllPositionsContext(ctx context.Context) (map[string]models.UserPosition, error) {
+	return c.AllPositions(ctx, "", PageOptions{})
+}
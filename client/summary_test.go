@@ -0,0 +1,39 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+func TestGetAccountSummaryTwoCurrencies(t *testing.T) {
+	c := newTestRestClient(t, twoCurrencyHandler)
+	summary, err := c.GetAccountSummary()
+	if err != nil {
+		t.Fatalf("GetAccountSummary: %v", err)
+	}
+	if err := summary.Err(); err != nil {
+		t.Fatalf("summary.Err: %v", err)
+	}
+	if !summary.MultiCurrency {
+		t.Error("MultiCurrency = false, want true")
+	}
+	if summary.TotalBalance != 0 || summary.TotalEquity != 0 {
+		t.Errorf("TotalBalance, TotalEquity = %v, %v, want zero for a multi-currency account",
+			summary.TotalBalance, summary.TotalEquity)
+	}
+
+	want := map[string]models.CurrencyTotals{
+		"USD": {Currency: "USD", TotalBalance: 120, BuyingPower: 100, OpenOrderExposure: 20,
+			PendingWithdrawals: 7.5, PositionsValue: 6, TotalEquity: 126},
+		"EUR": {Currency: "EUR", TotalBalance: 10, BuyingPower: 5, PositionsValue: 2, TotalEquity: 12},
+	}
+	if len(summary.ByCurrency) != len(want) {
+		t.Fatalf("ByCurrency = %+v, want %d currencies", summary.ByCurrency, len(want))
+	}
+	for currency, w := range want {
+		if got := summary.ByCurrency[currency]; got != w {
+			t.Errorf("ByCurrency[%s] = %+v, want %+v", currency, got, w)
+		}
+	}
+}
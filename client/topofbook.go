@@ -0,0 +1,110 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// TopOfBookWatcher reduces a full-depth market data stream to top-of-book
+// changes, calling OnChange only when a market's best bid or offer changes
+// in price or size. Use Observe as the OnMarketData handler of a
+// SubscribeMarketData consumer, or let SubscribeTopOfBook wire it up:
+//
+//	watcher := &client.TopOfBookWatcher{OnChange: func(t models.TopOfBook) { ... }}
+//	handlers := &client.Handlers{OnMarketData: watcher.Observe}
+//
+// Doc: api-reference/websocket/markets.mdx - Market Data Response
+type TopOfBookWatcher struct {
+	OnChange func(models.TopOfBook)
+
+	mu   sync.Mutex
+	last map[string]models.TopOfBook
+}
+
+// Observe processes one market data update, calling OnChange if the top of
+// the book differs from the last one seen for the market. The first update
+// for a market always counts as a change.
+func (w *TopOfBookWatcher) Observe(md *models.MarketDataUpdate) {
+	if md == nil || w.OnChange == nil {
+		return
+	}
+	top := models.NewOrderBook(md).Top()
+
+	w.mu.Lock()
+	if w.last == nil {
+		w.last = make(map[string]models.TopOfBook)
+	}
+	prev, seen := w.last[md.MarketSlug]
+	changed := !seen || !prev.SameLevels(top)
+	if changed {
+		w.last[md.MarketSlug] = top
+	}
+	w.mu.Unlock()
+
+	if changed {
+		w.OnChange(top)
+	}
+}
+
+// SubscribeTopOfBook subscribes to full-depth market data for marketSlugs
+// and calls onChange whenever a market's best bid or offer changes. The full
+// book is subscribed, rather than depth 1, so the top stays accurate when
+// the best level is removed. It returns the subscription's request ID;
+// Unsubscribe it to stop the callbacks.
+//
+// onChange runs on the read goroutine, before messages reach Messages, and
+// must not block. The market data messages are still queued on Messages as
+// usual.
+// Doc: api-reference/websocket/markets.mdx - Market Data Subscription
+func (c *WSClient) SubscribeTopOfBook(marketSlugs []string, onChange func(models.TopOfBook)) (string, error) {
+	if onChange == nil {
+		return "", fmt.Errorf("onChange is required")
+	}
+	watcher := &TopOfBookWatcher{OnChange: onChange}
+
+	// The observer is registered before subscribing so the first book is
+	// not missed; until the request ID is known, updates are matched by
+	// market alone. Observers cannot be removed, so once the subscription is
+	// gone (or never started) this one ignores everything.
+	var (
+		mu        sync.Mutex
+		requestID string
+		failed    bool
+	)
+	markets := make(map[string]bool, len(marketSlugs))
+	for _, slug := range marketSlugs {
+		markets[slug] = true
+	}
+	c.observe(func(msg *models.WSMessage) {
+		md := msg.MarketData
+		if md == nil || (len(markets) > 0 && !markets[md.MarketSlug]) {
+			return
+		}
+		mu.Lock()
+		id, stopped := requestID, failed
+		mu.Unlock()
+		if stopped || (id != "" && (msg.RequestID != id || !c.hasSubscription(id))) {
+			return
+		}
+		watcher.Observe(md)
+	})
+
+	id, err := c.SubscribeMarketData(marketSlugs, 0, false)
+	mu.Lock()
+	requestID, failed = id, err != nil
+	mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// hasSubscription reports whether requestID is a registered subscription.
+func (c *WSClient) hasSubscription(requestID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.subscriptions[requestID]
+	return ok
+}
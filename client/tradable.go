@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// marketCheckTTL is how long a market seen open for trading is trusted
+// before CreateOrder looks it up again. A market that closes within this
+// window is still caught by the server's rejection.
+const marketCheckTTL = time.Minute
+
+// MarketNotTradableError is returned by CreateOrder for an order on a market
+// that is not open for trading. State is the reason found by the local check
+// ("closed", "archived", or "inactive") or, when the server rejected the
+// order, the server's message; Err is set in the latter case.
+type MarketNotTradableError struct {
+	MarketSlug string
+	State      string
+	Err        *APIError
+}
+
+func (e *MarketNotTradableError) Error() string {
+	return fmt.Sprintf("market %s is not tradable: %s", e.MarketSlug, e.State)
+}
+
+func (e *MarketNotTradableError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// CheckMarketTradable looks up slug and returns a *MarketNotTradableError if
+// the market is closed, archived, or inactive.
+// Doc: api-reference/market/overview.mdx - GET /v1/market/slug/{slug}
+func (c *RestClient) CheckMarketTradable(slug string) error {
+	return c.CheckMarketTradableContext(context.Background(), slug)
+}
+
+// CheckMarketTradableContext is CheckMarketTradable bounded by ctx.
+func (c *RestClient) CheckMarketTradableContext(ctx context.Context, slug string) error {
+	m, err := c.GetMarketBySlugContext(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("failed to get market %s: %w", slug, err)
+	}
+	if reason := m.NotTradableReason(); reason != "" {
+		return &MarketNotTradableError{MarketSlug: slug, State: reason}
+	}
+	return nil
+}
+
+// guardMarket runs CheckMarketTradable for CreateOrder, remembering markets
+// found tradable for marketCheckTTL. A failed lookup does not block the
+// order; the server remains authoritative. The lookup is bounded by ctx.
+func (c *RestClient) guardMarket(ctx context.Context, slug string) error {
+	c.tradableMu.Lock()
+	checked, ok := c.tradableAt[slug]
+	c.tradableMu.Unlock()
+	if ok && time.Since(checked) < marketCheckTTL {
+		return nil
+	}
+
+	err := c.CheckMarketTradableContext(ctx, slug)
+	var notTradable *MarketNotTradableError
+	if errors.As(err, &notTradable) {
+		return err
+	}
+	if err == nil {
+		c.tradableMu.Lock()
+		c.tradableAt[slug] = time.Now()
+		c.tradableMu.Unlock()
+	}
+	return nil
+}
+
+// notTradableTerms are words in error codes, with underscores and hyphens
+// read as spaces, that indicate an order was rejected because of the
+// market's state.
+// Note: the API does not document a specific error code for this.
+var notTradableTerms = []string{
+	"closed", "halted", "suspended", "expired", "terminated",
+	"not open", "not active", "inactive", "not tradable",
+}
+
+// asMarketNotTradable converts an API error rejecting an order because of
+// the market's state into a *MarketNotTradableError, or returns nil. Only
+// the error code is matched: it must mention the market and one of
+// notTradableTerms. Messages are free text and are not matched.
+func asMarketNotTradable(err error, slug string) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	code := strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(apiErr.Code))
+	if !strings.Contains(code, "market") {
+		return nil
+	}
+	for _, term := range notTradableTerms {
+		if strings.Contains(code, term) {
+			state := apiErr.Message
+			if state == "" {
+				state = apiErr.Code
+			}
+			return &MarketNotTradableError{MarketSlug: slug, State: state, Err: apiErr}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+func TestAsMarketNotTradable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"market closed code", &APIError{StatusCode: 400, Code: "MARKET_CLOSED"}, true},
+		{"market not tradable code", &APIError{StatusCode: 400, Code: "market-not-tradable"}, true},
+		{"market halted code", &APIError{StatusCode: 400, Code: "ERR_MARKET_HALTED", Message: "try later"}, true},
+		{"message only", &APIError{StatusCode: 400, Code: "INVALID_ORDER", Message: "market is closed"}, false},
+		{"no code", &APIError{StatusCode: 400, Message: "market closed"}, false},
+		{"code without market", &APIError{StatusCode: 400, Code: "ORDER_EXPIRED"}, false},
+		{"not an API error", errors.New("market closed"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := asMarketNotTradable(tt.err, "test-market")
+			var notTradable *MarketNotTradableError
+			if got := errors.As(err, &notTradable); got != tt.want {
+				t.Errorf("asMarketNotTradable(%v) = %v, want MarketNotTradableError: %v", tt.err, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarketCheckOptIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []ClientOption
+		wantLookups int32
+		wantErr     bool
+	}{
+		{"default", nil, 0, false},
+		{"enabled", []ClientOption{WithMarketCheck(true)}, 1, true},
+		{"disabled", []ClientOption{WithMarketCheck(false)}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups atomic.Int32
+			c := newTestRestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/market/slug/") {
+					lookups.Add(1)
+					w.Write([]byte(`{"slug":"test-market","closed":true}`))
+					return
+				}
+				w.Write([]byte(`{"id":"order-1"}`))
+			}), tt.opts...)
+
+			price := &models.Amount{Value: "0.55", Currency: "USD"}
+			_, err := c.CreateOrder(models.NewLimitOrder("test-market", models.OrderIntentRequestBuyYes, price, 10))
+			var notTradable *MarketNotTradableError
+			if got := errors.As(err, &notTradable); got != tt.wantErr {
+				t.Errorf("CreateOrder = %v, want MarketNotTradableError: %v", err, tt.wantErr)
+			}
+			if got := lookups.Load(); got != tt.wantLookups {
+				t.Errorf("market lookups = %d, want %d", got, tt.wantLookups)
+			}
+		})
+	}
+}
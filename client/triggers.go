@@ -0,0 +1,194 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+// TriggerCondition is a predicate over a market data update.
+type TriggerCondition func(*models.MarketDataUpdate) bool
+
+// Trigger places or cancels an order once a condition is met. Exactly one of
+// Create or CancelOrderID should be set. A trigger fires at most once: when
+// Condition holds for an update in MarketSlug, or when At is reached,
+// whichever comes first.
+type Trigger struct {
+	Name       string
+	MarketSlug string
+	Condition  TriggerCondition // Optional if At is set
+	At         time.Time        // Optional: fire at this time regardless of market data
+
+	Create        *models.CreateOrderRequest
+	CancelOrderID string
+
+	// OnFired, if set, is called after the action with its result.
+	OnFired func(name string, err error)
+}
+
+// TriggerManager evaluates client-side triggers against the market data
+// stream.
+//
+// Note: the API has no server-side contingent orders. Triggers run in this
+// process and are best-effort: they act only on updates the client receives,
+// so they are subject to feed latency, debouncing, and dropped messages, and
+// they stop working if the process exits or the stream disconnects.
+type TriggerManager struct {
+	rest *RestClient
+
+	mu       sync.Mutex
+	triggers map[string]*armedTrigger
+}
+
+// armedTrigger is a registered trigger and its optional timer.
+type armedTrigger struct {
+	trigger Trigger
+	timer   *time.Timer
+}
+
+// NewTriggerManager creates a manager that acts through rest. Pass its
+// Observe method as Handlers.OnMarketData.
+func NewTriggerManager(rest *RestClient) *TriggerManager {
+	return &TriggerManager{
+		rest:     rest,
+		triggers: make(map[string]*armedTrigger),
+	}
+}
+
+// Add registers t under t.Name, replacing any trigger with the same name.
+func (m *TriggerManager) Add(t Trigger) error {
+	if t.Name == "" {
+		return fmt.Errorf("trigger name is required")
+	}
+	if (t.Create == nil) == (t.CancelOrderID == "") {
+		return fmt.Errorf("trigger %s must set exactly one of Create or CancelOrderID", t.Name)
+	}
+	if t.CancelOrderID != "" && t.MarketSlug == "" {
+		return fmt.Errorf("trigger %s must set MarketSlug to cancel an order", t.Name)
+	}
+	if t.Condition == nil && t.At.IsZero() {
+		return fmt.Errorf("trigger %s needs a Condition or At", t.Name)
+	}
+	if t.Create != nil {
+		if err := t.Create.Validate(); err != nil {
+			return fmt.Errorf("trigger %s: invalid order: %w", t.Name, err)
+		}
+	}
+
+	armed := &armedTrigger{trigger: t}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.triggers[t.Name]; ok && old.timer != nil {
+		old.timer.Stop()
+	}
+	m.triggers[t.Name] = armed
+	if !t.At.IsZero() {
+		armed.timer = time.AfterFunc(time.Until(t.At), func() {
+			m.fire(t.Name, armed)
+		})
+	}
+	return nil
+}
+
+// Remove disarms the named trigger.
+func (m *TriggerManager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if armed, ok := m.triggers[name]; ok {
+		if armed.timer != nil {
+			armed.timer.Stop()
+		}
+		delete(m.triggers, name)
+	}
+}
+
+// Observe evaluates every trigger for md's market and fires those whose
+// condition holds.
+func (m *TriggerManager) Observe(md *models.MarketDataUpdate) {
+	m.mu.Lock()
+	var due []*armedTrigger
+	for _, armed := range m.triggers {
+		t := armed.trigger
+		if t.Condition != nil && t.MarketSlug == md.MarketSlug && t.Condition(md) {
+			due = append(due, armed)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, armed := range due {
+		m.fire(armed.trigger.Name, armed)
+	}
+}
+
+// fire runs the trigger's action once, removing it first so a concurrent
+// update or timer cannot fire it again.
+func (m *TriggerManager) fire(name string, armed *armedTrigger) {
+	m.mu.Lock()
+	if m.triggers[name] != armed {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.triggers, name)
+	if armed.timer != nil {
+		armed.timer.Stop()
+	}
+	m.mu.Unlock()
+
+	t := armed.trigger
+	go func() {
+		var err error
+		if t.Create != nil {
+			_, err = m.rest.CreateOrder(t.Create)
+		} else {
+			err = m.rest.CancelOrder(t.CancelOrderID, t.MarketSlug)
+		}
+		if t.OnFired != nil {
+			t.OnFired(t.Name, err)
+		}
+	}()
+}
+
+// BestBidBelow holds when the best bid is below price. It returns an error
+// if price is not a plain decimal.
+func BestBidBelow(price string) (TriggerCondition, error) {
+	return levelCondition(price, (*models.OrderBook).BestBid, -1)
+}
+
+// BestAskAbove holds when the best ask is above price. It returns an error
+// if price is not a plain decimal.
+func BestAskAbove(price string) (TriggerCondition, error) {
+	return levelCondition(price, (*models.OrderBook).BestAsk, 1)
+}
+
+// SpreadAbove holds when the bid/ask spread is wider than spread. It returns
+// an error if spread is not a plain decimal.
+func SpreadAbove(spread string) (TriggerCondition, error) {
+	limit, err := models.ParseDecimal(spread)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spread: %w", err)
+	}
+	return func(md *models.MarketDataUpdate) bool {
+		s, err := models.NewOrderBook(md).Spread()
+		return err == nil && s.Cmp(limit) > 0
+	}, nil
+}
+
+// levelCondition compares the price of one side's best level with price;
+// it holds when the comparison equals want (-1 below, 1 above).
+func levelCondition(price string, best func(*models.OrderBook) *models.PriceLevel, want int) (TriggerCondition, error) {
+	limit, err := models.ParseDecimal(price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+	return func(md *models.MarketDataUpdate) bool {
+		level := best(models.NewOrderBook(md))
+		if level == nil {
+			return false
+		}
+		px, err := level.Px.Rat()
+		return err == nil && px.Cmp(limit) == want
+	}, nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/polymarket/retail-sample-client-go/models"
+)
+
+func TestTriggerConditions(t *testing.T) {
+	md := &models.MarketDataUpdate{
+		MarketSlug: "test-market",
+		Bids:       []models.PriceLevel{{Px: &models.Amount{Value: "0.40", Currency: "USD"}, Qty: "10"}},
+		Offers:     []models.PriceLevel{{Px: &models.Amount{Value: "0.60", Currency: "USD"}, Qty: "10"}},
+	}
+	tests := []struct {
+		name    string
+		newCond func(string) (TriggerCondition, error)
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{"bid below", BestBidBelow, "0.45", true, false},
+		{"bid not below", BestBidBelow, "0.40", false, false},
+		{"ask above", BestAskAbove, "0.55", true, false},
+		{"ask not above", BestAskAbove, "0.65", false, false},
+		{"spread above", SpreadAbove, "0.1", true, false},
+		{"spread not above", SpreadAbove, "0.2", false, false},
+		{"invalid bid price", BestBidBelow, ".45", false, true},
+		{"invalid ask price", BestAskAbove, "55c", false, true},
+		{"invalid spread", SpreadAbove, "1/10", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, err := tt.newCond(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("constructor(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := cond(md); got != tt.want {
+				t.Errorf("condition(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
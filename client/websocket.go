@@ -5,10 +5,14 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"sync"
 	"time"
 
@@ -19,94 +23,343 @@ import (
 	"github.com/polymarket/retail-sample-client-go/models"
 )
 
+// ErrSubscribeTimeout is returned by AwaitSubscription when the server neither
+// acknowledged nor rejected a subscription within the subscribe timeout.
+var ErrSubscribeTimeout = errors.New("subscription not acknowledged before timeout")
+
+// ErrWriteTimeout is returned when a WebSocket write does not complete within
+// the write timeout. The affected connection is closed.
+var ErrWriteTimeout = errors.New("WebSocket write timed out")
+
+// ErrHandshakeRejected is returned by Connect when the server refuses the
+// WebSocket handshake with HTTP 401 or 403, usually because of bad
+// credentials or clock skew. Connect does not retry it.
+var ErrHandshakeRejected = errors.New("WebSocket handshake rejected")
+
+// SubscriptionError reports a subscription the server rejected. Rejections
+// of a first subscribe remove the subscription; rejections of a replay
+// after a reconnect (Replay set) leave it registered but disabled, so it is
+// no longer replayed until Resubscribe. A disabled subscription is also
+// reported as a ConnectionEventSubscriptionFailed event.
+type SubscriptionError struct {
+	RequestID   string
+	Stream      string // StreamPrivate or StreamMarkets
+	MarketSlugs []string
+	Reason      string // The server's error text
+	Replay      bool
+}
+
+func (e *SubscriptionError) Error() string {
+	return fmt.Sprintf("subscription %s rejected: %s", e.RequestID, e.Reason)
+}
+
 // WSClient is a WebSocket client for real-time data.
 // Doc: api-reference/websocket/overview.mdx
 type WSClient struct {
-	config       *config.Config
-	privateConn  *websocket.Conn
-	marketsConn  *websocket.Conn
-	privateURL   string
-	marketsURL   string
-	mu           sync.Mutex
-	done         chan struct{}
-	messages     chan *models.WSMessage
-	requestID    int
-	connected    bool
-	reconnecting bool
-}
-
-// NewWSClient creates a new WebSocket client.
-func NewWSClient(cfg *config.Config) *WSClient {
+	configMu         sync.RWMutex // guards config, replaced by UpdateCredentials
+	config           *config.Config
+	privateConn      *websocket.Conn
+	marketsConn      *websocket.Conn
+	privateURL       string
+	marketsURL       string
+	mu               sync.Mutex
+	done             chan struct{}
+	messages         chan *models.WSMessage
+	requestID        int
+	privateStatus    StreamStatus // written only via setStatus
+	marketsStatus    StreamStatus // written only via setStatus
+	subscriptions    map[string]*subscription
+	groups           map[string]*SubscriptionGroup
+	observers        []func(*models.WSMessage)
+	events           chan ConnectionEvent
+	subscribeTimeout time.Duration
+	writeTimeout     time.Duration
+	autoReconnect    bool
+	maxSlugs         int                  // chunk size for SubscribeGroup
+	maxLifetime      time.Duration        // recycle connections older than this; 0 disables
+	maxReconnects    int                  // consecutive failed reconnect dials before giving up; 0 is unlimited
+	connectAttempts  int                  // dials Connect makes before giving up
+	strict           bool                 // reject unknown message fields
+	lifetimeTimers   map[bool]*time.Timer // keyed by private
+	connectedAt      map[bool]time.Time   // when each stream's current connection came up
+	flaps            map[bool]int         // consecutive connections that dropped before minStableConnection
+	heartbeats       map[bool]time.Time   // when each stream last received a heartbeat
+	aliases          map[string]string    // wire request ID -> original, for reissued subscriptions
+	disconnected     chan struct{}        // closed when a stream drops and will not be reconnected
+	disconnectOnce   sync.Once
+}
+
+// StreamStatus is the state of one WebSocket connection.
+type StreamStatus int
+
+const (
+	StreamClosed       StreamStatus = iota // Not connected, or dropped
+	StreamConnected                        // Connected and reading
+	StreamReconnecting                     // Dropped and being re-established
+)
+
+// String returns a lowercase label for the status.
+func (s StreamStatus) String() string {
+	switch s {
+	case StreamConnected:
+		return "connected"
+	case StreamReconnecting:
+		return "reconnecting"
+	}
+	return "closed"
+}
+
+// ConnectionState reports the status of each WebSocket stream.
+type ConnectionState struct {
+	Private StreamStatus `json:"private"`
+	Markets StreamStatus `json:"markets"`
+}
+
+// subscription records a subscribe request sent on one of the connections.
+// It is pending until the first message carrying its request ID arrives.
+// After a reconnect it is replayed under the same request ID; wireID differs
+// from request.RequestID only if the server refused the reused ID.
+type subscription struct {
+	request *models.WSSubscription
+	private bool
+	wireID  string
+	replay  bool // pending a replay after reconnect
+	acked   bool
+	err     error
+	timer   *time.Timer
+	settled chan struct{} // closed once acked, rejected, or timed out
+
+	allMarkets bool // no slug filter; replayed without market_slugs
+	replayed   bool // sent again after a reconnect or Resubscribe
+	disabled   bool // rejected on replay; skipped by reconnects until Resubscribe
+	timedOut   bool // no message before the subscribe timeout; still registered
+}
+
+// defaultMessageBuffer is the default capacity of the Messages channel.
+const defaultMessageBuffer = 100
+
+// NewWSClient creates a new WebSocket client. Options override the
+// timeouts, reconnect behavior, and buffering taken from cfg.
+func NewWSClient(cfg *config.Config, opts ...ClientOption) *WSClient {
+	o := applyOptions(opts)
+
+	subscribeTimeout := cfg.WSSubscribeTimeout
+	if o.subscribeTimeout > 0 {
+		subscribeTimeout = o.subscribeTimeout
+	}
+	if subscribeTimeout <= 0 {
+		subscribeTimeout = config.DefaultWSSubscribeTimeout
+	}
+	writeTimeout := cfg.WSWriteTimeout
+	if o.writeTimeout > 0 {
+		writeTimeout = o.writeTimeout
+	}
+	if writeTimeout <= 0 {
+		writeTimeout = config.DefaultWSWriteTimeout
+	}
+	autoReconnect := cfg.WSAutoReconnect
+	if o.autoReconnect != nil {
+		autoReconnect = *o.autoReconnect
+	}
+	messageBuffer := defaultMessageBuffer
+	if o.messageBuffer > 0 {
+		messageBuffer = o.messageBuffer
+	}
+	maxSlugs := DefaultMaxSlugsPerSubscription
+	if o.maxSlugs > 0 {
+		maxSlugs = o.maxSlugs
+	}
+	maxLifetime := cfg.WSMaxConnectionLifetime
+	if o.maxLifetime > 0 {
+		maxLifetime = o.maxLifetime
+	}
+	maxReconnects := cfg.WSMaxReconnectAttempts
+	if o.maxReconnects != nil {
+		maxReconnects = *o.maxReconnects
+	}
+	connectAttempts := cfg.WSConnectAttempts
+	if o.connectAttempts > 0 {
+		connectAttempts = o.connectAttempts
+	}
+	if connectAttempts <= 0 {
+		connectAttempts = config.DefaultWSConnectAttempts
+	}
+
 	return &WSClient{
-		config:     cfg,
-		privateURL: cfg.WSPrivateURL,
-		marketsURL: cfg.WSMarketsURL,
-		done:       make(chan struct{}),
-		messages:   make(chan *models.WSMessage, 100),
+		config:           cfg,
+		privateURL:       cfg.WSPrivateURL,
+		marketsURL:       cfg.WSMarketsURL,
+		done:             make(chan struct{}),
+		messages:         make(chan *models.WSMessage, messageBuffer),
+		subscriptions:    make(map[string]*subscription),
+		groups:           make(map[string]*SubscriptionGroup),
+		events:           make(chan ConnectionEvent, 16),
+		subscribeTimeout: subscribeTimeout,
+		writeTimeout:     writeTimeout,
+		autoReconnect:    autoReconnect,
+		maxSlugs:         maxSlugs,
+		maxLifetime:      maxLifetime,
+		maxReconnects:    maxReconnects,
+		connectAttempts:  connectAttempts,
+		strict:           o.strictDecode,
+		lifetimeTimers:   make(map[bool]*time.Timer),
+		connectedAt:      make(map[bool]time.Time),
+		flaps:            make(map[bool]int),
+		heartbeats:       make(map[bool]time.Time),
+		aliases:          make(map[string]string),
+		disconnected:     make(chan struct{}),
 	}
 }
 
-// Connect establishes WebSocket connections.
+// Connect establishes WebSocket connections. Without credentials (see
+// config.LoadPublic), only the markets stream is connected; private
+// subscriptions then fail with auth.ErrNoCredentials.
+//
+// A failed dial is retried with backoff up to Config.WSConnectAttempts
+// times in all; see ConnectContext.
 // Doc: api-reference/websocket/overview.mdx - Connection
 func (c *WSClient) Connect() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.ConnectContext(context.Background())
+}
 
-	// Configure TLS for staging/development with self-signed certs
-	var tlsConfig *tls.Config
-	if c.config.InsecureSkipVerify {
-		tlsConfig = &tls.Config{
-			InsecureSkipVerify: true,
+// ConnectContext is Connect bounded by ctx: ending ctx aborts a dial in
+// progress and stops further retries. Network
+// failures are retried with the reconnect backoff; a handshake rejected for
+// authentication (ErrHandshakeRejected) fails at once, as retrying with the
+// same credentials cannot succeed.
+// Doc: api-reference/websocket/overview.mdx - Connection
+func (c *WSClient) ConnectContext(ctx context.Context) error {
+	delay := reconnectBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := c.connect(ctx)
+		if err == nil || errors.Is(err, ErrHandshakeRejected) || attempt >= c.connectAttempts {
+			return err
 		}
+		log.Printf("[WS] Connect attempt %d of %d failed, retrying in %v: %v",
+			attempt, c.connectAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-c.done:
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, reconnectMaxDelay)
 	}
+}
+
+// connect dials both streams once, bounded by ctx.
+func (c *WSClient) connect(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	// Connect to private WebSocket
 	// Doc: api-reference/websocket/private.mdx - Endpoint
-	privateHeaders := auth.GenerateWSHeaders(c.config)
-	privateDialer := websocket.Dialer{
-		HandshakeTimeout: 10 * time.Second,
-		TLSClientConfig:  tlsConfig,
-	}
-
-	privateConn, _, err := privateDialer.Dial(c.privateURL, privateHeaders)
-	if err != nil {
-		return fmt.Errorf("failed to connect to private WebSocket: %w", err)
+	var privateConn *websocket.Conn
+	if c.currentConfig().HasCredentials() {
+		var err error
+		privateConn, err = c.dial(ctx, true)
+		if err != nil {
+			return fmt.Errorf("failed to connect to private WebSocket: %w", err)
+		}
+		c.privateConn = privateConn
+		log.Printf("[WS] Connected to private WebSocket: %s", c.privateURL)
+	} else {
+		log.Printf("[WS] No credentials configured, skipping private WebSocket")
 	}
-	c.privateConn = privateConn
-	log.Printf("[WS] Connected to private WebSocket: %s", c.privateURL)
 
 	// Connect to markets WebSocket
 	// Doc: api-reference/websocket/markets.mdx - Endpoint
-	marketsHeaders := auth.GenerateWSMarketsHeaders(c.config)
-	marketsDialer := websocket.Dialer{
-		HandshakeTimeout: 10 * time.Second,
-		TLSClientConfig:  tlsConfig,
-	}
-
-	marketsConn, _, err := marketsDialer.Dial(c.marketsURL, marketsHeaders)
+	marketsConn, err := c.dial(ctx, false)
 	if err != nil {
-		c.privateConn.Close()
+		if privateConn != nil {
+			privateConn.Close()
+		}
 		return fmt.Errorf("failed to connect to markets WebSocket: %w", err)
 	}
 	c.marketsConn = marketsConn
 	log.Printf("[WS] Connected to markets WebSocket: %s", c.marketsURL)
 
-	c.connected = true
-
-	// Start reading from both connections
-	go c.readPrivate()
-	go c.readMarkets()
+	if privateConn != nil {
+		c.setStatus(true, StreamConnected)
+		c.emit(ConnectionEvent{Type: ConnectionEventConnected, Stream: StreamPrivate})
+		go c.readPrivate(privateConn)
+		c.armLifetime(true)
+	}
+	c.setStatus(false, StreamConnected)
+	c.emit(ConnectionEvent{Type: ConnectionEventConnected, Stream: StreamMarkets})
+	go c.readMarkets(marketsConn)
+	c.armLifetime(false)
 
 	return nil
 }
 
-// Close closes WebSocket connections.
+// dial opens one stream with freshly signed handshake headers. ctx bounds
+// the dial and handshake.
+func (c *WSClient) dial(ctx context.Context, private bool) (*websocket.Conn, error) {
+	// Configure TLS for staging/development with self-signed certs
+	cfg := c.currentConfig()
+	var tlsConfig *tls.Config
+	if cfg.InsecureSkipVerify {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  tlsConfig,
+	}
+
+	var (
+		conn *websocket.Conn
+		resp *http.Response
+		err  error
+	)
+	if private {
+		conn, resp, err = dialer.DialContext(ctx, c.privateURL, auth.GenerateWSHeaders(cfg))
+	} else {
+		conn, resp, err = dialer.DialContext(ctx, c.marketsURL, auth.GenerateWSMarketsHeaders(cfg))
+	}
+	if err != nil && resp != nil &&
+		(resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: HTTP %d", ErrHandshakeRejected, resp.StatusCode)
+	}
+	return conn, err
+}
+
+// Close closes WebSocket connections. Subscriptions still awaiting an
+// acknowledgement are settled with an error, so AwaitSubscription returns.
+// Closing an already closed client does nothing.
 func (c *WSClient) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	select {
+	case <-c.done:
+		return nil
+	default:
+	}
 	close(c.done)
 
+	for _, sub := range c.subscriptions {
+		if sub.timer != nil {
+			sub.timer.Stop()
+		}
+		if !sub.acked && sub.err == nil {
+			sub.err = errClientClosed
+			close(sub.settled)
+		}
+	}
+	for _, timer := range c.lifetimeTimers {
+		timer.Stop()
+	}
+
 	var errs []error
 	if c.privateConn != nil {
 		if err := c.privateConn.Close(); err != nil {
@@ -119,7 +372,8 @@ func (c *WSClient) Close() error {
 		}
 	}
 
-	c.connected = false
+	c.setStatus(true, StreamClosed)
+	c.setStatus(false, StreamClosed)
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing connections: %v", errs)
@@ -140,85 +394,231 @@ func (c *WSClient) nextRequestID(prefix string) string {
 	return fmt.Sprintf("%s-%d", prefix, c.requestID)
 }
 
+// markDisconnected records that the read loop for conn has exited with err
+// and emits a Disconnected event describing why. Loops for connections that
+// have already been replaced (see recycle) exit silently.
+func (c *WSClient) markDisconnected(private bool, conn *websocket.Conn, err error) {
+	stream := StreamMarkets
+	c.mu.Lock()
+	if private {
+		if conn != c.privateConn {
+			c.mu.Unlock()
+			return
+		}
+		stream = StreamPrivate
+	} else if conn != c.marketsConn {
+		c.mu.Unlock()
+		return
+	}
+	c.setStatus(private, StreamClosed)
+	if time.Since(c.connectedAt[private]) < minStableConnection {
+		c.flaps[private]++
+	} else {
+		c.flaps[private] = 0
+	}
+	c.mu.Unlock()
+
+	event := ConnectionEvent{Type: ConnectionEventDisconnected, Stream: stream, Err: err}
+	select {
+	case <-c.done:
+		event.Reason = CloseReasonClientClosed
+	default:
+		event.Reason, event.Code, event.Text = classifyClose(err)
+		log.Printf("[WS] %s connection ended: %s (code %d, reason %q, retryable: %t)",
+			stream, event.Reason, event.Code, event.Text, event.Reason.Retryable())
+	}
+	c.emit(event)
+
+	if event.Reason != CloseReasonClientClosed && c.autoReconnect && event.Reason.Retryable() {
+		c.mu.Lock()
+		c.setStatus(private, StreamReconnecting)
+		c.mu.Unlock()
+		go c.reconnect(private)
+		return
+	}
+
+	c.disconnectOnce.Do(func() {
+		close(c.disconnected)
+	})
+}
+
+// wsFrame is the result of one ReadMessage call.
+type wsFrame struct {
+	data []byte
+	err  error
+}
+
+// readFrames calls ReadMessage on conn in its own goroutine and delivers
+// each result on the returned channel, so read loops can block on frames
+// and c.done together. The goroutine stops after the first error, or once
+// c.done is closed (Close then unblocks its pending read by closing conn).
+//
+// Note: reading cannot resume on a connection after a read error, even a
+// temporary net error such as a timeout. gorilla/websocket keeps the first
+// read error and returns it from every later ReadMessage (and panics after
+// many repeated reads), so there is no way to ride out a blip on the same
+// connection. To keep transient stalls from surfacing as errors at all, no
+// read deadline is set; a read error therefore means the connection is
+// gone, and recovery is a reconnect with subscription replay (see
+// markDisconnected).
+func (c *WSClient) readFrames(conn *websocket.Conn) <-chan wsFrame {
+	frames := make(chan wsFrame)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			select {
+			case frames <- wsFrame{data: data, err: err}:
+			case <-c.done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return frames
+}
+
 // readPrivate reads messages from the private WebSocket.
-func (c *WSClient) readPrivate() {
+func (c *WSClient) readPrivate(conn *websocket.Conn) {
+	var readErr error
+	defer func() { c.markDisconnected(true, conn, readErr) }()
+	frames := c.readFrames(conn)
 	for {
+		var frame wsFrame
 		select {
 		case <-c.done:
 			return
-		default:
-			_, message, err := c.privateConn.ReadMessage()
-			if err != nil {
-				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-					log.Printf("[WS] Private connection closed normally")
-					return
-				}
-				log.Printf("[WS] Error reading from private WebSocket: %v", err)
+		case frame = <-frames:
+		}
+
+		if frame.err != nil {
+			// The connection cannot be read again after any error, so
+			// return rather than retry; markDisconnected classifies the
+			// error and reconnects on a fresh connection if it is
+			// transient.
+			readErr = frame.err
+			if websocket.IsCloseError(frame.err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("[WS] Private connection closed normally")
 				return
 			}
+			log.Printf("[WS] Error reading from private WebSocket: %v", frame.err)
+			return
+		}
 
-			var msg models.WSMessage
-			if err := json.Unmarshal(message, &msg); err != nil {
-				log.Printf("[WS] Failed to parse private message: %v", err)
-				continue
-			}
+		var msg models.WSMessage
+		if err := decodeJSON(frame.data, &msg, c.strict); err != nil {
+			log.Printf("[WS] Failed to parse private message: %v", err)
+			continue
+		}
+		msg.RequestID = c.originalRequestID(msg.RequestID)
+		if c.settleSubscription(msg.RequestID, msg.Error) {
+			continue
+		}
 
-			// Handle heartbeat
-			// Doc: api-reference/websocket/overview.mdx - Heartbeats
-			if msg.Heartbeat != nil {
-				log.Printf("[WS] Private heartbeat received")
-				continue
-			}
+		// Handle heartbeat
+		// Doc: api-reference/websocket/overview.mdx - Heartbeats
+		if msg.Heartbeat != nil {
+			log.Printf("[WS] Private heartbeat received")
+			c.noteHeartbeat(true)
+			continue
+		}
 
-			// Send to channel
-			select {
-			case c.messages <- &msg:
-			default:
-				log.Printf("[WS] Message channel full, dropping message")
-			}
+		c.notifyObservers(&msg)
+
+		// Send to channel
+		select {
+		case c.messages <- &msg:
+		default:
+			log.Printf("[WS] Message channel full, dropping message")
 		}
 	}
 }
 
 // readMarkets reads messages from the markets WebSocket.
-func (c *WSClient) readMarkets() {
+func (c *WSClient) readMarkets(conn *websocket.Conn) {
+	var readErr error
+	defer func() { c.markDisconnected(false, conn, readErr) }()
+	frames := c.readFrames(conn)
 	for {
+		var frame wsFrame
 		select {
 		case <-c.done:
 			return
-		default:
-			_, message, err := c.marketsConn.ReadMessage()
-			if err != nil {
-				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-					log.Printf("[WS] Markets connection closed normally")
-					return
-				}
-				log.Printf("[WS] Error reading from markets WebSocket: %v", err)
+		case frame = <-frames:
+		}
+
+		if frame.err != nil {
+			// The connection cannot be read again after any error, so
+			// return rather than retry; markDisconnected classifies the
+			// error and reconnects on a fresh connection if it is
+			// transient.
+			readErr = frame.err
+			if websocket.IsCloseError(frame.err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("[WS] Markets connection closed normally")
 				return
 			}
+			log.Printf("[WS] Error reading from markets WebSocket: %v", frame.err)
+			return
+		}
 
-			var msg models.WSMessage
-			if err := json.Unmarshal(message, &msg); err != nil {
-				log.Printf("[WS] Failed to parse markets message: %v", err)
-				continue
-			}
+		var msg models.WSMessage
+		if err := decodeJSON(frame.data, &msg, c.strict); err != nil {
+			log.Printf("[WS] Failed to parse markets message: %v", err)
+			continue
+		}
+		msg.RequestID = c.originalRequestID(msg.RequestID)
+		if c.settleSubscription(msg.RequestID, msg.Error) {
+			continue
+		}
+		c.applyDepth(&msg)
 
-			// Handle heartbeat
-			if msg.Heartbeat != nil {
-				log.Printf("[WS] Markets heartbeat received")
-				continue
-			}
+		// Handle heartbeat
+		if msg.Heartbeat != nil {
+			log.Printf("[WS] Markets heartbeat received")
+			c.noteHeartbeat(false)
+			continue
+		}
 
-			// Send to channel
-			select {
-			case c.messages <- &msg:
-			default:
-				log.Printf("[WS] Message channel full, dropping message")
-			}
+		c.notifyObservers(&msg)
+
+		// Send to channel
+		select {
+		case c.messages <- &msg:
+		default:
+			log.Printf("[WS] Message channel full, dropping message")
 		}
 	}
 }
 
+// noteHeartbeat records when a stream last received a heartbeat.
+func (c *WSClient) noteHeartbeat(private bool) {
+	c.mu.Lock()
+	c.heartbeats[private] = time.Now()
+	c.mu.Unlock()
+}
+
+// observe registers fn to see every non-heartbeat message on the read
+// goroutine, before it is queued on the Messages channel. Observers let the
+// library keep internal state (e.g. cached balances) without competing with
+// the application for messages; they must not block.
+func (c *WSClient) observe(fn func(*models.WSMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observers = append(c.observers, fn)
+}
+
+// notifyObservers passes msg to every registered observer.
+func (c *WSClient) notifyObservers(msg *models.WSMessage) {
+	c.mu.Lock()
+	observers := c.observers
+	c.mu.Unlock()
+
+	for _, fn := range observers {
+		fn(msg)
+	}
+}
+
 // sendPrivate sends a message on the private WebSocket.
 func (c *WSClient) sendPrivate(msg interface{}) error {
 	c.mu.Lock()
@@ -227,13 +627,7 @@ func (c *WSClient) sendPrivate(msg interface{}) error {
 	if c.privateConn == nil {
 		return fmt.Errorf("private WebSocket not connected")
 	}
-
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
-	}
-
-	return c.privateConn.WriteMessage(websocket.TextMessage, data)
+	return c.write(c.privateConn, "private", msg)
 }
 
 // sendMarkets sends a message on the markets WebSocket.
@@ -244,31 +638,221 @@ func (c *WSClient) sendMarkets(msg interface{}) error {
 	if c.marketsConn == nil {
 		return fmt.Errorf("markets WebSocket not connected")
 	}
+	return c.write(c.marketsConn, "markets", msg)
+}
 
+// write marshals msg and writes it to conn under the write deadline.
+// A write that times out leaves the connection unusable, so the connection
+// is closed; its read loop then exits and reports the disconnect.
+// Callers must hold c.mu.
+func (c *WSClient) write(conn *websocket.Conn, name string, msg interface{}) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	return c.marketsConn.WriteMessage(websocket.TextMessage, data)
+	if err := conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		log.Printf("[WS] Write to %s WebSocket timed out after %s, closing connection", name, c.writeTimeout)
+		conn.Close()
+		return fmt.Errorf("%w: %s WebSocket: %w", ErrWriteTimeout, name, err)
+	}
+	return err
+}
+
+// subscribe registers a subscription as pending and sends it on the private
+// or markets connection. The entry is registered before sending so an
+// immediate server response cannot race the bookkeeping.
+func (c *WSClient) subscribe(req *models.WSSubscription, private bool) error {
+	if private && !c.currentConfig().HasCredentials() {
+		return fmt.Errorf("private subscription requires authentication: %w", auth.ErrNoCredentials)
+	}
+	slugs, err := validateSlugs(req.MarketSlugs)
+	if err != nil {
+		return err
+	}
+	req.MarketSlugs = slugs
+
+	sub := &subscription{
+		request:    req,
+		private:    private,
+		allMarkets: len(req.MarketSlugs) == 0,
+		wireID:     req.RequestID,
+		settled:    make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	c.subscriptions[req.RequestID] = sub
+	sub.timer = time.AfterFunc(c.subscribeTimeout, func() {
+		c.expireSubscription(req.RequestID)
+	})
+	c.mu.Unlock()
+
+	msg := &models.WSSubscribeRequest{Subscribe: req}
+
+	if private {
+		err = c.sendPrivate(msg)
+	} else {
+		err = c.sendMarkets(msg)
+	}
+	if err != nil {
+		c.mu.Lock()
+		sub.timer.Stop()
+		delete(c.subscriptions, req.RequestID)
+		c.mu.Unlock()
+		return err
+	}
+	return nil
 }
 
-// SubscribeOrders subscribes to order updates.
+// settleSubscription marks a pending subscription as acknowledged on the
+// first message carrying its request ID. An error response rejects the
+// subscription with a *SubscriptionError, removing it from the registry, or
+// disabling it if it was being replayed. It reports whether the
+// message was handled internally and should not reach consumers.
+func (c *WSClient) settleSubscription(requestID, errMsg string) bool {
+	if requestID == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub, ok := c.subscriptions[requestID]
+	if !ok || sub.acked {
+		return false
+	}
+	if sub.timedOut {
+		// AwaitSubscription already reported the timeout, but the server
+		// has the subscription: a late message marks it active, a late
+		// rejection removes it.
+		sub.timedOut = false
+		if errMsg != "" {
+			delete(c.subscriptions, requestID)
+			delete(c.aliases, sub.wireID)
+			log.Printf("[WS] Subscription %s rejected after timing out: %s", requestID, errMsg)
+			return false
+		}
+		sub.acked = true
+		sub.err = nil
+		return false
+	}
+	if sub.err != nil {
+		return false
+	}
+	sub.timer.Stop()
+
+	if errMsg != "" && sub.replay && sub.wireID == requestID {
+		// The server may refuse a request ID it has seen before; resubscribe
+		// under a fresh wire ID and translate it back for consumers.
+		c.requestID++
+		sub.wireID = fmt.Sprintf("%s~%d", requestID, c.requestID)
+		sub.replay = false
+		c.aliases[sub.wireID] = requestID
+		sub.timer.Reset(c.subscribeTimeout)
+		log.Printf("[WS] Replay of %s rejected (%s), resubscribing as %s", requestID, errMsg, sub.wireID)
+		go c.sendSubscription(sub)
+		return true
+	}
+	sub.replay = false
+
+	if errMsg != "" {
+		subErr := &SubscriptionError{
+			RequestID:   requestID,
+			Stream:      streamName(sub.private),
+			MarketSlugs: sub.request.MarketSlugs,
+			Reason:      errMsg,
+			Replay:      sub.replayed,
+		}
+		sub.err = subErr
+		delete(c.aliases, sub.wireID)
+		if sub.replayed {
+			// Replaying it on every reconnect would only be rejected again;
+			// keep it registered but disabled so Resubscribe can retry it.
+			sub.disabled = true
+			log.Printf("[WS] Subscription %s rejected on replay (%s), disabled until resubscribed", requestID, errMsg)
+			c.emit(ConnectionEvent{Type: ConnectionEventSubscriptionFailed, Stream: subErr.Stream, Err: subErr})
+		} else {
+			delete(c.subscriptions, requestID)
+		}
+	} else {
+		sub.acked = true
+	}
+	close(sub.settled)
+	return false
+}
+
+// expireSubscription settles a subscription that received no message within
+// the subscribe timeout with ErrSubscribeTimeout. The entry stays registered
+// and unacknowledged: a quiet stream (e.g. trades on an idle market) sends
+// nothing, yet the server keeps the subscription live, so it must remain
+// reachable by Unsubscribe and be replayed on reconnect.
+func (c *WSClient) expireSubscription(requestID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub, ok := c.subscriptions[requestID]
+	if !ok || sub.acked || sub.err != nil {
+		return
+	}
+	sub.err = ErrSubscribeTimeout
+	sub.timedOut = true
+	close(sub.settled)
+
+	log.Printf("[WS] Subscription %s not acknowledged within %s, keeping it registered", requestID, c.subscribeTimeout)
+}
+
+// AwaitSubscription blocks until the subscription identified by requestID is
+// acknowledged, rejected, or times out. It returns nil once acknowledged,
+// a *SubscriptionError on rejection, and ErrSubscribeTimeout on expiry.
+// Call it immediately after subscribing: rejected entries are removed from
+// the registry, except disabled replays (see Resubscribe). Expired entries
+// stay registered, as the server may still hold them; call Unsubscribe to
+// drop one.
+func (c *WSClient) AwaitSubscription(requestID string) error {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[requestID]
+	var settled chan struct{}
+	if ok {
+		settled = sub.settled
+	}
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown subscription %q", requestID)
+	}
+
+	<-settled
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return sub.err
+}
+
+// SubscribeOrders subscribes to order updates for marketSlugs. An empty
+// list subscribes account-wide, to orders in every market, including markets
+// traded later; a list only covers those markets, so fills elsewhere are
+// missed. See SubscribeAllOrders.
 // Doc: api-reference/websocket/private.mdx - Order Subscriptions
 func (c *WSClient) SubscribeOrders(marketSlugs []string) (string, error) {
 	requestID := c.nextRequestID("order")
 
 	// Doc: api-reference/websocket/private.mdx - Subscribe to Orders
 	// "Leave marketSlugs empty to subscribe to all markets"
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:        requestID,
-			SubscriptionType: models.SubscriptionTypeOrder,
-			MarketSlugs:      marketSlugs,
-		},
+	req := &models.WSSubscription{
+		RequestID:        requestID,
+		SubscriptionType: models.SubscriptionTypeOrder,
+		MarketSlugs:      marketSlugs,
 	}
 
-	if err := c.sendPrivate(msg); err != nil {
+	if err := c.subscribe(req, true); err != nil {
 		return "", err
 	}
 
@@ -276,20 +860,26 @@ func (c *WSClient) SubscribeOrders(marketSlugs []string) (string, error) {
 	return requestID, nil
 }
 
+// SubscribeAllOrders subscribes to order updates in every market. Prefer it
+// when feeding Client.Orders, KillSwitch, or anything else that needs a
+// complete view of the account's orders.
+// Doc: api-reference/websocket/private.mdx - Subscribe to Orders
+func (c *WSClient) SubscribeAllOrders() (string, error) {
+	return c.SubscribeOrders(nil)
+}
+
 // SubscribePositions subscribes to position updates.
 // Doc: api-reference/websocket/private.mdx - Position Subscriptions
 func (c *WSClient) SubscribePositions(marketSlugs []string) (string, error) {
 	requestID := c.nextRequestID("position")
 
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:        requestID,
-			SubscriptionType: models.SubscriptionTypePosition,
-			MarketSlugs:      marketSlugs,
-		},
+	req := &models.WSSubscription{
+		RequestID:        requestID,
+		SubscriptionType: models.SubscriptionTypePosition,
+		MarketSlugs:      marketSlugs,
 	}
 
-	if err := c.sendPrivate(msg); err != nil {
+	if err := c.subscribe(req, true); err != nil {
 		return "", err
 	}
 
@@ -302,14 +892,12 @@ func (c *WSClient) SubscribePositions(marketSlugs []string) (string, error) {
 func (c *WSClient) SubscribeBalances() (string, error) {
 	requestID := c.nextRequestID("balance")
 
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:        requestID,
-			SubscriptionType: models.SubscriptionTypeAccountBalance,
-		},
+	req := &models.WSSubscription{
+		RequestID:        requestID,
+		SubscriptionType: models.SubscriptionTypeAccountBalance,
 	}
 
-	if err := c.sendPrivate(msg); err != nil {
+	if err := c.subscribe(req, true); err != nil {
 		return "", err
 	}
 
@@ -317,44 +905,86 @@ func (c *WSClient) SubscribeBalances() (string, error) {
 	return requestID, nil
 }
 
-// SubscribeMarketData subscribes to full market data (order book).
+// SubscribeMarketData subscribes to market data (order book).
+// depth limits each side of the book to the top N levels; pass 0 for the
+// full book. The subscription protocol documents no depth field, so the
+// server sends the full book and the limit is applied client-side;
+// consumers never see more than depth levels per side.
 // Doc: api-reference/websocket/markets.mdx - Market Data Subscription
-func (c *WSClient) SubscribeMarketData(marketSlugs []string, debounced bool) (string, error) {
+func (c *WSClient) SubscribeMarketData(marketSlugs []string, depth int, debounced bool) (string, error) {
+	if depth < 0 {
+		return "", fmt.Errorf("invalid depth %d: must be positive, or 0 for the full book", depth)
+	}
+
 	requestID := c.nextRequestID("marketdata")
 
 	// Doc: api-reference/websocket/markets.mdx - Debouncing
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:          requestID,
-			SubscriptionType:   models.SubscriptionTypeMarketData,
-			MarketSlugs:        marketSlugs,
-			ResponsesDebounced: debounced,
-		},
+	req := &models.WSSubscription{
+		RequestID:          requestID,
+		SubscriptionType:   models.SubscriptionTypeMarketData,
+		MarketSlugs:        marketSlugs,
+		ResponsesDebounced: debounced,
+		Depth:              depth,
 	}
 
-	if err := c.sendMarkets(msg); err != nil {
+	if err := c.subscribe(req, false); err != nil {
 		return "", err
 	}
 
-	log.Printf("[WS] Subscribed to market data (requestId: %s, markets: %v, debounced: %t)",
-		requestID, marketSlugs, debounced)
+	log.Printf("[WS] Subscribed to market data (requestId: %s, markets: %v, depth: %d, debounced: %t)",
+		requestID, marketSlugs, depth, debounced)
 	return requestID, nil
 }
 
+// applyDepth truncates a market data message to the depth requested by the
+// subscription it belongs to. Only full market data subscriptions carry a
+// depth; a lite subscription on the same market never limits the book.
+func (c *WSClient) applyDepth(msg *models.WSMessage) {
+	if msg.MarketData == nil || msg.RequestID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	sub, ok := c.subscriptions[msg.RequestID]
+	depth := 0
+	if ok && sub.request.SubscriptionType == models.SubscriptionTypeMarketData {
+		depth = sub.request.Depth
+	}
+	c.mu.Unlock()
+
+	if depth <= 0 {
+		return
+	}
+	md := msg.MarketData
+	if len(md.Bids) > depth {
+		md.Bids = md.Bids[:depth]
+	}
+	if len(md.Offers) > depth {
+		md.Offers = md.Offers[:depth]
+	}
+}
+
 // SubscribeMarketDataLite subscribes to lightweight price data.
+//
+// A market may be subscribed to both the full and the lite feed at once,
+// e.g. lite for a broad watchlist and full for a few traded markets. The two
+// subscriptions are independent: each has its own request ID, is
+// acknowledged, replayed and unsubscribed on its own, and the server sends
+// both message types for the market. Nothing is merged or deduplicated;
+// MarketData and MarketDataLite messages reach OnMarketData and
+// OnMarketDataLite respectively, and a full subscription's depth limit does
+// not apply to lite messages.
 // Doc: api-reference/websocket/markets.mdx - Market Data Lite Subscription
 func (c *WSClient) SubscribeMarketDataLite(marketSlugs []string) (string, error) {
 	requestID := c.nextRequestID("marketdatalite")
 
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:        requestID,
-			SubscriptionType: models.SubscriptionTypeMarketDataLite,
-			MarketSlugs:      marketSlugs,
-		},
+	req := &models.WSSubscription{
+		RequestID:        requestID,
+		SubscriptionType: models.SubscriptionTypeMarketDataLite,
+		MarketSlugs:      marketSlugs,
 	}
 
-	if err := c.sendMarkets(msg); err != nil {
+	if err := c.subscribe(req, false); err != nil {
 		return "", err
 	}
 
@@ -367,15 +997,13 @@ func (c *WSClient) SubscribeMarketDataLite(marketSlugs []string) (string, error)
 func (c *WSClient) SubscribeTrades(marketSlugs []string) (string, error) {
 	requestID := c.nextRequestID("trade")
 
-	msg := &models.WSSubscribeRequest{
-		Subscribe: &models.WSSubscription{
-			RequestID:        requestID,
-			SubscriptionType: models.SubscriptionTypeTrade,
-			MarketSlugs:      marketSlugs,
-		},
+	req := &models.WSSubscription{
+		RequestID:        requestID,
+		SubscriptionType: models.SubscriptionTypeTrade,
+		MarketSlugs:      marketSlugs,
 	}
 
-	if err := c.sendMarkets(msg); err != nil {
+	if err := c.subscribe(req, false); err != nil {
 		return "", err
 	}
 
@@ -383,24 +1011,87 @@ func (c *WSClient) SubscribeTrades(marketSlugs []string) (string, error) {
 	return requestID, nil
 }
 
-// Unsubscribe cancels a subscription.
+// Unsubscribe cancels a subscription. The unsubscribe message is routed to
+// the connection the subscription was made on, looked up by requestID.
+// requestID is always the ID returned when subscribing, even after the
+// subscription has been replayed on a new connection.
 // Doc: api-reference/websocket/overview.mdx - Unsubscribing
-func (c *WSClient) Unsubscribe(requestID string, isPrivate bool) error {
+func (c *WSClient) Unsubscribe(requestID string) error {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[requestID]
+	if ok && sub.disabled {
+		// The server never accepted the replay; there is nothing to cancel.
+		delete(c.subscriptions, requestID)
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown subscription %q", requestID)
+	}
+
 	msg := &models.WSUnsubscribeRequest{
 		Unsubscribe: &models.WSUnsubscription{
-			RequestID: requestID,
+			RequestID: sub.wireID,
 		},
 	}
 
-	if isPrivate {
-		return c.sendPrivate(msg)
+	var err error
+	if sub.private {
+		err = c.sendPrivate(msg)
+	} else {
+		err = c.sendMarkets(msg)
+	}
+	if err != nil {
+		return err
 	}
-	return c.sendMarkets(msg)
+
+	c.mu.Lock()
+	sub.timer.Stop()
+	delete(c.subscriptions, requestID)
+	delete(c.aliases, sub.wireID)
+	c.mu.Unlock()
+	return nil
 }
 
-// IsConnected returns whether the client is connected.
+// IsConnected returns whether both the private and markets streams are up.
+// Without credentials, only the markets stream is considered.
 func (c *WSClient) IsConnected() bool {
+	public := !c.currentConfig().HasCredentials()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return (public || c.status(true) == StreamConnected) && c.status(false) == StreamConnected
+}
+
+// ConnectionState returns the status of each stream, so callers can tell
+// which one is down and whether REST fallbacks are needed.
+func (c *WSClient) ConnectionState() ConnectionState {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.connected
+	return ConnectionState{
+		Private: c.status(true),
+		Markets: c.status(false),
+	}
+}
+
+// setStatus records a stream's status and, when it comes up, its connection
+// time. Every status transition goes through here so the fields are only
+// written under c.mu. Callers must hold c.mu.
+func (c *WSClient) setStatus(private bool, s StreamStatus) {
+	if private {
+		c.privateStatus = s
+	} else {
+		c.marketsStatus = s
+	}
+	if s == StreamConnected {
+		c.connectedAt[private] = time.Now()
+	}
+}
+
+// status returns a stream's status. Callers must hold c.mu.
+func (c *WSClient) status(private bool) StreamStatus {
+	if private {
+		return c.privateStatus
+	}
+	return c.marketsStatus
 }
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/polymarket/retail-sample-client-go/config"
+)
+
+// newSilentWSServer starts a WebSocket server that accepts connections and
+// reads without ever answering, so subscriptions stay pending.
+func newSilentWSServer(t *testing.T) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestCloseSettlesPendingSubscriptions(t *testing.T) {
+	url := newSilentWSServer(t)
+	c := NewWSClient(&config.Config{WSMarketsURL: url}, WithSubscribeTimeout(time.Hour))
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	requestID, err := c.SubscribeMarketDataLite([]string{"test-market"})
+	if err != nil {
+		t.Fatalf("SubscribeMarketDataLite: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- c.AwaitSubscription(requestID) }()
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case err := <-result:
+		if !errors.Is(err, errClientClosed) {
+			t.Errorf("AwaitSubscription = %v, want errClientClosed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AwaitSubscription still blocked after Close")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
+
+// clientGoroutines returns the stacks of goroutines started by a WSClient.
+func clientGoroutines() []string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	var stacks []string
+	for _, g := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(g, "created by github.com/polymarket/retail-sample-client-go/client.(*WSClient)") {
+			stacks = append(stacks, g)
+		}
+	}
+	return stacks
+}
+
+func TestCloseStopsReadGoroutines(t *testing.T) {
+	url := newSilentWSServer(t)
+	c := NewWSClient(&config.Config{WSMarketsURL: url})
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if len(clientGoroutines()) == 0 {
+		t.Fatal("no client goroutines after Connect")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(clientGoroutines()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines still running 2s after Close:\n%s", strings.Join(clientGoroutines(), "\n\n"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStreamStatusConcurrentAccess(t *testing.T) {
+	c := NewWSClient(&config.Config{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(private bool) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.mu.Lock()
+				c.setStatus(private, StreamStatus(j%3))
+				c.mu.Unlock()
+			}
+		}(i%2 == 0)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.IsConnected()
+				c.ConnectionState()
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	c.setStatus(true, StreamConnected)
+	c.setStatus(false, StreamConnected)
+	c.mu.Unlock()
+	if got := c.ConnectionState(); got.Private != StreamConnected || got.Markets != StreamConnected {
+		t.Errorf("ConnectionState = %+v, want both connected", got)
+	}
+}
+
+func TestConnectContextAbortsDial(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // Never complete the handshake
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	c := NewWSClient(&config.Config{WSMarketsURL: "ws" + strings.TrimPrefix(srv.URL, "http")})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := c.ConnectContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ConnectContext = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ConnectContext returned after %v, want about the 100ms deadline", elapsed)
+	}
+}
+
+func TestExpiredSubscriptionStaysRegistered(t *testing.T) {
+	url := newSilentWSServer(t)
+	c := NewWSClient(&config.Config{WSMarketsURL: url}, WithSubscribeTimeout(50*time.Millisecond))
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	requestID, err := c.SubscribeTrades([]string{"quiet-market"})
+	if err != nil {
+		t.Fatalf("SubscribeTrades: %v", err)
+	}
+	if err := c.AwaitSubscription(requestID); !errors.Is(err, ErrSubscribeTimeout) {
+		t.Fatalf("AwaitSubscription = %v, want ErrSubscribeTimeout", err)
+	}
+
+	c.mu.Lock()
+	_, registered := c.subscriptions[requestID]
+	c.mu.Unlock()
+	if !registered {
+		t.Fatal("expired subscription was dropped from the registry")
+	}
+	if c.settleSubscription(requestID, "") {
+		t.Error("late message was consumed, want it delivered")
+	}
+	c.mu.Lock()
+	acked := c.subscriptions[requestID].acked
+	c.mu.Unlock()
+	if !acked {
+		t.Error("late message did not mark the subscription active")
+	}
+	if err := c.Unsubscribe(requestID); err != nil {
+		t.Errorf("Unsubscribe after timeout: %v", err)
+	}
+}
+
+func TestReconnectClosesDroppedConnection(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		conns int
+	)
+	closed := make(chan bool, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		conns++
+		first := conns == 1
+		mu.Unlock()
+		if first {
+			// Drop the client with a retryable close, then wait for it to
+			// close the TCP connection once it has reconnected.
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseServiceRestart, "restart"),
+				time.Now().Add(time.Second))
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					break
+				}
+			}
+			raw := conn.UnderlyingConn()
+			raw.SetReadDeadline(time.Now().Add(5 * time.Second))
+			_, err := raw.Read(make([]byte, 1))
+			var netErr interface{ Timeout() bool }
+			closed <- !(errors.As(err, &netErr) && netErr.Timeout())
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	c := NewWSClient(&config.Config{WSMarketsURL: "ws" + strings.TrimPrefix(srv.URL, "http")}, WithAutoReconnect(true))
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case ok := <-closed:
+		if !ok {
+			t.Error("dropped connection was left open after reconnecting")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("client did not reconnect")
+	}
+}
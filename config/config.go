@@ -5,7 +5,11 @@ package config
 import (
 	"encoding/base64"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/ed25519"
 )
@@ -13,6 +17,10 @@ import (
 // Config holds all configuration for the Polymarket API client.
 // Environment variables are documented in CLAUDE.md.
 type Config struct {
+	// Environment is the named preset the configuration was built from.
+	// Env: POLYMARKET_ENV (prod or staging, default: prod)
+	Environment string
+
 	// APIKey is the API key ID (UUID) for authentication.
 	// Env: POLYMARKET_API_KEY
 	// Doc: api/authentication.mdx - X-PM-Access-Key header
@@ -33,6 +41,10 @@ type Config struct {
 	// Doc: api-reference/oapi-schemas/orders-schema.json - servers section
 	BaseURL string
 
+	// APIVersion is the version segment prefixed to every API path.
+	// Env: POLYMARKET_API_VERSION (default: v1)
+	APIVersion string
+
 	// WSPrivateURL is the WebSocket URL for private data.
 	// Doc: api-reference/websocket/private.mdx - endpoint
 	WSPrivateURL string
@@ -45,8 +57,66 @@ type Config struct {
 	// Use only for staging/development with self-signed certs.
 	// Env: INSECURE_SKIP_VERIFY=true
 	InsecureSkipVerify bool
+
+	// WSSubscribeTimeout is how long a subscription may remain unacknowledged
+	// before it is dropped from the pending set.
+	// Env: POLYMARKET_WS_SUBSCRIBE_TIMEOUT (Go duration, default: 10s)
+	WSSubscribeTimeout time.Duration
+
+	// WSWriteTimeout bounds each WebSocket write. A write that exceeds it
+	// closes the connection rather than blocking the caller.
+	// Env: POLYMARKET_WS_WRITE_TIMEOUT (Go duration, default: 10s)
+	WSWriteTimeout time.Duration
+
+	// WSAutoReconnect re-dials a stream that drops for a retryable reason and
+	// replays its subscriptions under their original request IDs.
+	// Env: POLYMARKET_WS_RECONNECT (default: true)
+	WSAutoReconnect bool
+
+	// WSMaxConnectionLifetime proactively replaces each WebSocket connection
+	// with a freshly signed one after this long. Handshake signatures are
+	// checked only at connect time and the protocol has no re-authentication
+	// message, so recycling is the way to refresh credentials on long-lived
+	// sessions.
+	// Note: no maximum server-side connection lifetime is documented.
+	// Env: POLYMARKET_WS_MAX_LIFETIME (Go duration, default: 0 = never)
+	WSMaxConnectionLifetime time.Duration
+
+	// WSMaxReconnectAttempts stops reconnecting a dropped stream after this
+	// many consecutive failed dials; the stream is then closed for good and
+	// a ConnectionEventReconnectFailed event is emitted. The count resets
+	// after every successful reconnect.
+	// Env: POLYMARKET_WS_MAX_RECONNECT_ATTEMPTS (default: 0 = unlimited)
+	WSMaxReconnectAttempts int
+
+	// WSConnectAttempts is how many times Connect dials before giving up, so
+	// a stream that is briefly unavailable at startup does not fail the
+	// whole connection. Rejected authentication is never retried.
+	// Env: POLYMARKET_WS_CONNECT_ATTEMPTS (default: 3)
+	WSConnectAttempts int
 }
 
+// Environment presets selectable with POLYMARKET_ENV.
+const (
+	EnvProduction = "prod"
+	EnvStaging    = "staging"
+)
+
+// ProductionBaseURL is the production API base URL.
+const ProductionBaseURL = "https://api.polymarket.us"
+
+// DefaultAPIVersion is used when POLYMARKET_API_VERSION is unset.
+const DefaultAPIVersion = "v1"
+
+// DefaultWSSubscribeTimeout is used when POLYMARKET_WS_SUBSCRIBE_TIMEOUT is unset.
+const DefaultWSSubscribeTimeout = 10 * time.Second
+
+// DefaultWSWriteTimeout is used when POLYMARKET_WS_WRITE_TIMEOUT is unset.
+const DefaultWSWriteTimeout = 10 * time.Second
+
+// DefaultWSConnectAttempts is used when POLYMARKET_WS_CONNECT_ATTEMPTS is unset.
+const DefaultWSConnectAttempts = 3
+
 // getEnvWithFallback returns the first non-empty value from the given env var names.
 // This allows the harness to set variables only if not already set.
 func getEnvWithFallback(names ...string) string {
@@ -58,6 +128,23 @@ func getEnvWithFallback(names ...string) string {
 	return ""
 }
 
+// getDurationEnv parses a Go duration (e.g. "5s") from the first non-empty
+// env var, returning def when none is set.
+func getDurationEnv(def time.Duration, names ...string) (time.Duration, error) {
+	val := getEnvWithFallback(names...)
+	if val == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q for %s: %w", val, names[0], err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %s", names[0], d)
+	}
+	return d, nil
+}
+
 // Load loads configuration from environment variables.
 // Variables are checked with fallbacks to support both direct usage and harness integration:
 //   - POLYMARKET_API_KEY or TEST_API_KEY_ID
@@ -65,15 +152,160 @@ func getEnvWithFallback(names ...string) string {
 //   - POLYMARKET_SYMBOL or TEST_MARKET_SLUG
 //   - POLYMARKET_BASE_URL or RETAIL_API_URL (default: https://api.polymarket.us)
 //   - POLYMARKET_WS_URL or RETAIL_WS_URL (default: derived from base URL)
+//   - POLYMARKET_API_VERSION (default: v1)
+//   - POLYMARKET_ENV (prod or staging, default: prod)
+//
+// The environment preset supplies defaults that explicit variables override.
+// prod defaults to ProductionBaseURL with TLS verification on; staging has no
+// public URL, so it requires POLYMARKET_BASE_URL, and defaults
+// INSECURE_SKIP_VERIFY to true for its self-signed certificates. Disabling
+// TLS verification against the production host is rejected.
 func Load() (*Config, error) {
-	// API Key: check POLYMARKET_API_KEY first, fall back to TEST_API_KEY_ID
+	return load(true)
+}
+
+// LoadPublic loads configuration like Load, but without requiring
+// credentials or a symbol. When neither POLYMARKET_API_KEY nor
+// POLYMARKET_PRIVATE_KEY is set, the config has no credentials
+// (HasCredentials is false) and clients built from it can only use public
+// market endpoints and the markets WebSocket. If either is set, both are
+// required, as with Load.
+func LoadPublic() (*Config, error) {
+	return load(false)
+}
+
+// load reads the environment; requireCredentials distinguishes Load from
+// LoadPublic.
+func load(requireCredentials bool) (*Config, error) {
+	// Credentials: check POLYMARKET_API_KEY / POLYMARKET_PRIVATE_KEY first,
+	// fall back to TEST_API_KEY_ID / TEST_API_SECRET_KEY
 	apiKey := getEnvWithFallback("POLYMARKET_API_KEY", "TEST_API_KEY_ID")
+	privateKeyB64 := getEnvWithFallback("POLYMARKET_PRIVATE_KEY", "TEST_API_SECRET_KEY")
+	public := !requireCredentials && apiKey == "" && privateKeyB64 == ""
+
+	var privateKey ed25519.PrivateKey
+	if !public {
+		var err error
+		if privateKey, err = loadCredentials(apiKey, privateKeyB64); err != nil {
+			return nil, err
+		}
+	}
+
+	// Symbol: check POLYMARKET_SYMBOL first, fall back to TEST_MARKET_SLUG
+	symbol := getEnvWithFallback("POLYMARKET_SYMBOL", "TEST_MARKET_SLUG")
+	if symbol == "" && requireCredentials {
+		return nil, fmt.Errorf("POLYMARKET_SYMBOL or TEST_MARKET_SLUG environment variable is required")
+	}
+
+	env := strings.ToLower(getEnvWithFallback("POLYMARKET_ENV"))
+	switch env {
+	case "", "production":
+		env = EnvProduction
+	case EnvProduction, EnvStaging:
+	default:
+		return nil, fmt.Errorf("invalid POLYMARKET_ENV %q: expected %q or %q", env, EnvProduction, EnvStaging)
+	}
+
+	// Base URL: check POLYMARKET_BASE_URL first, fall back to RETAIL_API_URL
+	baseURL := getEnvWithFallback("POLYMARKET_BASE_URL", "RETAIL_API_URL")
+	if baseURL == "" {
+		if env == EnvStaging {
+			return nil, fmt.Errorf("POLYMARKET_ENV=staging requires POLYMARKET_BASE_URL or RETAIL_API_URL")
+		}
+		baseURL = ProductionBaseURL
+	}
+	// A base URL may carry a mount prefix (e.g. https://host/api); paths are
+	// appended to it, so drop any trailing slash.
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	// API version: every REST and WebSocket path is built under /{version}
+	apiVersion := strings.Trim(getEnvWithFallback("POLYMARKET_API_VERSION"), "/")
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+
+	// WebSocket URL: check POLYMARKET_WS_URL first, fall back to RETAIL_WS_URL
+	// Doc: api-reference/websocket/overview.mdx - endpoints
+	wsBaseURL := getEnvWithFallback("POLYMARKET_WS_URL", "RETAIL_WS_URL")
+	if wsBaseURL == "" {
+		// Derive from base URL by replacing https with wss
+		wsBaseURL = baseURL
+		if len(wsBaseURL) > 5 && wsBaseURL[:5] == "https" {
+			wsBaseURL = "wss" + wsBaseURL[5:]
+		} else if len(wsBaseURL) > 4 && wsBaseURL[:4] == "http" {
+			wsBaseURL = "ws" + wsBaseURL[4:]
+		}
+	}
+	wsBaseURL = strings.TrimRight(wsBaseURL, "/")
+
+	// Check if TLS verification should be skipped (for staging with self-signed certs).
+	// An explicit INSECURE_SKIP_VERIFY overrides the preset default.
+	insecureSkipVerify := env == EnvStaging
+	if val := getEnvWithFallback("INSECURE_SKIP_VERIFY"); val != "" {
+		insecureSkipVerify = val == "true"
+	}
+	if insecureSkipVerify && (isProductionHost(baseURL) || isProductionHost(wsBaseURL)) {
+		return nil, fmt.Errorf("INSECURE_SKIP_VERIFY=true is not allowed against production (%s)", baseURL)
+	}
+
+	subscribeTimeout, err := getDurationEnv(DefaultWSSubscribeTimeout, "POLYMARKET_WS_SUBSCRIBE_TIMEOUT")
+	if err != nil {
+		return nil, err
+	}
+
+	writeTimeout, err := getDurationEnv(DefaultWSWriteTimeout, "POLYMARKET_WS_WRITE_TIMEOUT")
+	if err != nil {
+		return nil, err
+	}
+
+	autoReconnect := getEnvWithFallback("POLYMARKET_WS_RECONNECT") != "false"
+
+	maxLifetime, err := getDurationEnv(0, "POLYMARKET_WS_MAX_LIFETIME")
+	if err != nil {
+		return nil, err
+	}
+
+	maxReconnectAttempts := 0
+	if val := getEnvWithFallback("POLYMARKET_WS_MAX_RECONNECT_ATTEMPTS"); val != "" {
+		maxReconnectAttempts, err = strconv.Atoi(val)
+		if err != nil || maxReconnectAttempts < 0 {
+			return nil, fmt.Errorf("invalid value %q for POLYMARKET_WS_MAX_RECONNECT_ATTEMPTS: must be a non-negative integer", val)
+		}
+	}
+
+	connectAttempts := DefaultWSConnectAttempts
+	if val := getEnvWithFallback("POLYMARKET_WS_CONNECT_ATTEMPTS"); val != "" {
+		connectAttempts, err = strconv.Atoi(val)
+		if err != nil || connectAttempts < 1 {
+			return nil, fmt.Errorf("invalid value %q for POLYMARKET_WS_CONNECT_ATTEMPTS: must be a positive integer", val)
+		}
+	}
+
+	return &Config{
+		Environment:             env,
+		APIKey:                  apiKey,
+		PrivateKey:              privateKey,
+		Symbol:                  symbol,
+		BaseURL:                 baseURL,
+		APIVersion:              apiVersion,
+		WSPrivateURL:            wsBaseURL + "/" + apiVersion + "/ws/private",
+		WSMarketsURL:            wsBaseURL + "/" + apiVersion + "/ws/markets",
+		InsecureSkipVerify:      insecureSkipVerify,
+		WSSubscribeTimeout:      subscribeTimeout,
+		WSWriteTimeout:          writeTimeout,
+		WSAutoReconnect:         autoReconnect,
+		WSMaxConnectionLifetime: maxLifetime,
+		WSMaxReconnectAttempts:  maxReconnectAttempts,
+		WSConnectAttempts:       connectAttempts,
+	}, nil
+}
+
+// loadCredentials validates the API key and decodes the base64 private key.
+func loadCredentials(apiKey, privateKeyB64 string) (ed25519.PrivateKey, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("POLYMARKET_API_KEY or TEST_API_KEY_ID environment variable is required")
 	}
 
-	// Private Key: check POLYMARKET_PRIVATE_KEY first, fall back to TEST_API_SECRET_KEY
-	privateKeyB64 := getEnvWithFallback("POLYMARKET_PRIVATE_KEY", "TEST_API_SECRET_KEY")
 	if privateKeyB64 == "" {
 		return nil, fmt.Errorf("POLYMARKET_PRIVATE_KEY or TEST_API_SECRET_KEY environment variable is required")
 	}
@@ -98,43 +330,80 @@ func Load() (*Config, error) {
 			ed25519.PrivateKeySize, ed25519.SeedSize, len(privateKeyBytes))
 	}
 
-	// Symbol: check POLYMARKET_SYMBOL first, fall back to TEST_MARKET_SLUG
-	symbol := getEnvWithFallback("POLYMARKET_SYMBOL", "TEST_MARKET_SLUG")
-	if symbol == "" {
-		return nil, fmt.Errorf("POLYMARKET_SYMBOL or TEST_MARKET_SLUG environment variable is required")
+	// Optional key-pair check: if the public key registered for the API key is
+	// provided, verify the private key actually belongs to it. Pairing an API
+	// key with the wrong private key otherwise surfaces only as rejected requests.
+	if expected := getEnvWithFallback("POLYMARKET_PUBLIC_KEY"); expected != "" {
+		if err := verifyPublicKey(privateKey, expected); err != nil {
+			return nil, err
+		}
 	}
 
-	// Base URL: check POLYMARKET_BASE_URL first, fall back to RETAIL_API_URL
-	baseURL := getEnvWithFallback("POLYMARKET_BASE_URL", "RETAIL_API_URL")
-	if baseURL == "" {
-		baseURL = "https://api.polymarket.us"
+	return privateKey, nil
+}
+
+// isProductionHost reports whether rawURL points at the production API
+// host, over either HTTP or WebSocket schemes. Host names are compared
+// case-insensitively and ports are ignored.
+func isProductionHost(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https", "http", "wss", "ws":
+	default:
+		return false
+	}
+	prod, err := url.Parse(ProductionBaseURL)
+	if err != nil {
+		return false
 	}
+	return strings.EqualFold(u.Hostname(), prod.Hostname())
+}
 
-	// WebSocket URL: check POLYMARKET_WS_URL first, fall back to RETAIL_WS_URL
-	// Doc: api-reference/websocket/overview.mdx - endpoints
-	wsBaseURL := getEnvWithFallback("POLYMARKET_WS_URL", "RETAIL_WS_URL")
-	if wsBaseURL == "" {
-		// Derive from base URL by replacing https with wss
-		wsBaseURL = baseURL
-		if len(wsBaseURL) > 5 && wsBaseURL[:5] == "https" {
-			wsBaseURL = "wss" + wsBaseURL[5:]
-		} else if len(wsBaseURL) > 4 && wsBaseURL[:4] == "http" {
-			wsBaseURL = "ws" + wsBaseURL[4:]
-		}
+// APIPath prefixes a version-relative path with the API version,
+// e.g. "/markets" becomes "/v1/markets".
+func (c *Config) APIPath(path string) string {
+	version := c.APIVersion
+	if version == "" {
+		version = DefaultAPIVersion
 	}
+	return "/" + version + path
+}
 
-	// Check if TLS verification should be skipped (for staging with self-signed certs)
-	insecureSkipVerify := getEnvWithFallback("INSECURE_SKIP_VERIFY") == "true"
+// HasCredentials reports whether an API key and a usable private key are
+// configured. Configs from LoadPublic may have neither.
+func (c *Config) HasCredentials() bool {
+	return c.APIKey != "" && len(c.PrivateKey) == ed25519.PrivateKeySize
+}
 
-	return &Config{
-		APIKey:             apiKey,
-		PrivateKey:         privateKey,
-		Symbol:             symbol,
-		BaseURL:            baseURL,
-		WSPrivateURL:       wsBaseURL + "/v1/ws/private",
-		WSMarketsURL:       wsBaseURL + "/v1/ws/markets",
-		InsecureSkipVerify: insecureSkipVerify,
-	}, nil
+// PublicKeyBase64 returns the base64-encoded Ed25519 public key derived from
+// the configured private key. Compare it with the public key shown for the
+// API key in the dashboard to confirm the key pair is correct.
+func (c *Config) PublicKeyBase64() string {
+	if len(c.PrivateKey) != ed25519.PrivateKeySize {
+		return ""
+	}
+	pub, ok := c.PrivateKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(pub)
+}
+
+// verifyPublicKey checks that privateKey derives the expected base64 public key.
+func verifyPublicKey(privateKey ed25519.PrivateKey, expectedB64 string) error {
+	expected, err := base64.StdEncoding.DecodeString(expectedB64)
+	if err != nil {
+		return fmt.Errorf("failed to decode POLYMARKET_PUBLIC_KEY: %w", err)
+	}
+	derived := privateKey.Public().(ed25519.PublicKey)
+	if !derived.Equal(ed25519.PublicKey(expected)) {
+		return fmt.Errorf("key mismatch: private key derives public key %s, but POLYMARKET_PUBLIC_KEY is %s",
+			base64.StdEncoding.EncodeToString(derived), expectedB64)
+	}
+	return nil
 }
 
 // MustLoad loads configuration or panics on error.
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestIsProductionHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://api.polymarket.us", true},
+		{"https://api.polymarket.us/v1/markets", true},
+		{"wss://api.polymarket.us/v1/ws/private", true},
+		{"https://API.Polymarket.US", true},
+		{"https://api.polymarket.us:443", true},
+		{"https://api.polymarket.us?x=1", true},
+		{"https://user@api.polymarket.us", true},
+		{"https://api.polymarket.us.staging.example", false},
+		{"https://staging.polymarket.us", false},
+		{"ftp://api.polymarket.us", false},
+		{"api.polymarket.us", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isProductionHost(tt.url); got != tt.want {
+			t.Errorf("isProductionHost(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
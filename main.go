@@ -11,6 +11,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -37,9 +38,24 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 	log.Printf("  API Key: %s...", cfg.APIKey[:8])
+	log.Printf("  Public Key: %s (verify against the dashboard)", cfg.PublicKeyBase64())
 	log.Printf("  Symbol: %s (configurable via POLYMARKET_SYMBOL)", cfg.Symbol)
 	log.Printf("  Base URL: %s", cfg.BaseURL)
 
+	// Preflight: keys, URLs, clock, and an authenticated request
+	// Doc: api/authentication.mdx - Timestamp Validation
+	diagnostics := client.RunDiagnostics(cfg)
+	for _, check := range diagnostics.Checks {
+		status := "OK"
+		if !check.Passed {
+			status = "FAILED"
+		}
+		log.Printf("  Check %s: %s (%s)", check.Name, status, check.Detail)
+	}
+	if !diagnostics.OK() {
+		log.Println("  Warning: preflight checks failed; requests below may be rejected")
+	}
+
 	// 2. Initialize clients
 	log.Println("\n[STEP 2] Initializing clients...")
 	restClient := client.NewRestClient(cfg)
@@ -110,9 +126,12 @@ func main() {
 		log.Printf("  Warning: Failed to get balances: %v", err)
 	} else {
 		for _, b := range balances.Balances {
-			log.Printf("  %s Balance: $%.2f", b.Currency, b.CurrentBalance)
-			log.Printf("  Buying Power: $%.2f", b.BuyingPower)
-			log.Printf("  Open Orders: $%.2f", b.OpenOrders)
+			log.Printf("  %s Balance: %.2f", b.Currency, b.CurrentBalance)
+			log.Printf("  %s Buying Power: %.2f", b.Currency, b.BuyingPower)
+			log.Printf("  %s Open Orders: %.2f", b.Currency, b.OpenOrders)
+			if pending := b.PendingWithdrawalTotal(); pending > 0 {
+				log.Printf("  %s Pending Withdrawals: %.2f", b.Currency, pending)
+			}
 		}
 	}
 
@@ -137,23 +156,31 @@ func main() {
 	// 7. Connect to WebSocket
 	// Doc: api-reference/websocket/overview.mdx - Connection
 	log.Println("\n[STEP 7] Connecting to WebSocket...")
+	wsConnected := false
 	if err := wsClient.Connect(); err != nil {
 		log.Printf("  Warning: Failed to connect WebSocket: %v", err)
 		log.Println("  (Continuing without real-time updates)")
 	} else {
+		wsConnected = true
 		log.Println("  WebSocket connected successfully")
 
 		// Start message handler with subscription tracking
-		go handleWSMessages(ctx, wsClient, &marketDataReceived, &marketDataMu,
+		handlers := newWSHandlers(&marketDataReceived, &marketDataMu,
 			&balanceSnapshotReceived, &positionUpdateReceived, &orderUpdateReceived, &subscriptionMu)
+		go func() {
+			if err := wsClient.Consume(ctx, handlers); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("[WS] Consumer stopped: %v", err)
+			}
+		}()
 
 		// 8. Subscribe to private streams
 		// Doc: api-reference/websocket/private.mdx - Subscription Types
 		log.Println("\n[STEP 8] Subscribing to private streams...")
 
-		// Subscribe to orders for our symbol
+		// Subscribe to orders in every market, so fills outside our symbol
+		// are not missed
 		// Doc: api-reference/websocket/private.mdx - Order Subscriptions
-		if _, err := wsClient.SubscribeOrders([]string{cfg.Symbol}); err != nil {
+		if _, err := wsClient.SubscribeAllOrders(); err != nil {
 			log.Printf("  Warning: Failed to subscribe to orders: %v", err)
 		}
 
@@ -173,9 +200,9 @@ func main() {
 		// Doc: api-reference/websocket/markets.mdx - Subscription Types
 		log.Println("\n[STEP 9] Subscribing to market streams...")
 
-		// Subscribe to market data (order book)
+		// Subscribe to market data (top 10 levels of the order book)
 		// Doc: api-reference/websocket/markets.mdx - Market Data Subscription
-		if _, err := wsClient.SubscribeMarketData([]string{cfg.Symbol}, true); err != nil {
+		if _, err := wsClient.SubscribeMarketData([]string{cfg.Symbol}, 10, true); err != nil {
 			log.Printf("  Warning: Failed to subscribe to market data: %v", err)
 		}
 
@@ -229,14 +256,20 @@ func main() {
 		log.Println("\n[STEP 11] Waiting for WebSocket order confirmation...")
 		time.Sleep(3 * time.Second)
 
-		// 12. Get order details via REST
+		// 12. Get order details via REST, waiting until it leaves PENDING_NEW
 		// Doc: api-reference/orders/overview.mdx - GET /v1/order/{orderId}
 		log.Println("\n[STEP 12] Getting order details...")
-		orderDetail, err := restClient.GetOrder(orderID)
-		if err != nil {
+		awaitCtx, cancelAwait := context.WithTimeout(context.Background(), 10*time.Second)
+		o, err := restClient.AwaitOrder(awaitCtx, orderID, func(o *models.Order) bool {
+			return o.State != models.OrderStatePendingNew
+		})
+		cancelAwait()
+		if err != nil && o == nil {
 			log.Printf("  Warning: Failed to get order: %v", err)
-		} else if orderDetail.Order != nil {
-			o := orderDetail.Order
+		} else {
+			if err != nil {
+				log.Printf("  Warning: %v", err)
+			}
 			log.Printf("  Order ID: %s", o.ID)
 			log.Printf("  State: %s", o.State)
 			log.Printf("  Side: %s, Type: %s", o.Side, o.Type)
@@ -257,7 +290,7 @@ func main() {
 			log.Printf("  Found %d open order(s)", len(openOrders.Orders))
 			for _, o := range openOrders.Orders {
 				log.Printf("    - %s: %s %s @ %s (qty: %.0f)",
-					o.ID, o.Side, o.Intent, o.Price.Value, o.Quantity)
+					o.ID, o.Side, o.Intent, o.Price.ValueOr("market"), o.Quantity)
 			}
 		}
 
@@ -283,8 +316,8 @@ func main() {
 		log.Printf("  Warning: Failed to get balances: %v", err)
 	} else {
 		for _, b := range finalBalances.Balances {
-			log.Printf("  %s Balance: $%.2f", b.Currency, b.CurrentBalance)
-			log.Printf("  Buying Power: $%.2f", b.BuyingPower)
+			log.Printf("  %s Balance: %.2f", b.Currency, b.CurrentBalance)
+			log.Printf("  %s Buying Power: %.2f", b.Currency, b.BuyingPower)
 		}
 	}
 
@@ -302,14 +335,14 @@ func main() {
 			log.Printf("  Found %d activities:", len(activities.Activities))
 			for _, a := range activities.Activities {
 				detail := ""
-				if a.Trade != nil {
-					detail = fmt.Sprintf("market=%s, qty=%s", a.Trade.MarketSlug, a.Trade.Qty)
-				} else if a.PositionResolution != nil {
-					detail = fmt.Sprintf("market=%s", a.PositionResolution.MarketSlug)
-				} else if a.AccountBalanceChange != nil {
-					detail = fmt.Sprintf("txn=%s", a.AccountBalanceChange.TransactionID)
+				if t, ok := a.AsTrade(); ok {
+					detail = fmt.Sprintf("market=%s, qty=%s", t.MarketSlug, t.Qty)
+				} else if r, ok := a.AsPositionResolution(); ok {
+					detail = fmt.Sprintf("market=%s", r.MarketSlug)
+				} else if c, ok := a.AsAccountBalanceChange(); ok {
+					detail = fmt.Sprintf("txn=%s", c.TransactionID)
 				}
-				log.Printf("    - %s: %s", a.Type, detail)
+				log.Printf("    - %s: %s", a.Kind(), detail)
 			}
 		}
 	}
@@ -342,7 +375,9 @@ func main() {
 
 	// 19. Clean shutdown
 	log.Println("\n[STEP 19] Cleaning up...")
-	if wsClient.IsConnected() {
+	if wsConnected {
+		state := wsClient.ConnectionState()
+		log.Printf("  Stream state: private=%s, markets=%s", state.Private, state.Markets)
 		if err := wsClient.Close(); err != nil {
 			log.Printf("  Warning: Error closing WebSocket: %v", err)
 		} else {
@@ -354,127 +389,114 @@ func main() {
 	log.Println("See CLAUDE.md for documentation references")
 }
 
-// handleWSMessages processes WebSocket messages.
-func handleWSMessages(ctx context.Context, wsClient *client.WSClient, marketData *[]string, mu *sync.Mutex,
-	balanceSnapshotReceived, positionUpdateReceived, orderUpdateReceived *bool, subscriptionMu *sync.Mutex) {
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case msg := <-wsClient.Messages():
-			if msg == nil {
-				continue
-			}
+// newWSHandlers builds the typed WebSocket callbacks used by the demo.
+func newWSHandlers(marketData *[]string, mu *sync.Mutex,
+	balanceSnapshotReceived, positionUpdateReceived, orderUpdateReceived *bool, subscriptionMu *sync.Mutex) *client.Handlers {
+	recordMarketData := func(summary string) {
+		mu.Lock()
+		*marketData = append(*marketData, summary)
+		mu.Unlock()
+	}
 
-			// Handle errors
-			if msg.Error != "" {
-				log.Printf("[WS] Error: %s (requestId: %s)", msg.Error, msg.RequestID)
-				continue
-			}
+	return &client.Handlers{
+		// Handle errors
+		OnError: func(requestID, err string) {
+			log.Printf("[WS] Error: %s (requestId: %s)", err, requestID)
+		},
 
-			// Handle order snapshot
-			// Doc: api-reference/websocket/private.mdx - Order Snapshot Response
-			if msg.OrderSubscriptionSnapshot != nil {
-				log.Printf("[WS] Order snapshot: %d orders", len(msg.OrderSubscriptionSnapshot.Orders))
-				for _, o := range msg.OrderSubscriptionSnapshot.Orders {
-					log.Printf("[WS]   - %s: %s %s @ %s", o.ID, o.State, o.Side, o.Price.Value)
-				}
-				subscriptionMu.Lock()
-				*orderUpdateReceived = true
-				subscriptionMu.Unlock()
+		// Handle order snapshot
+		// Doc: api-reference/websocket/private.mdx - Order Snapshot Response
+		OnOrderSnapshot: func(snapshot *models.OrderSnapshot) {
+			log.Printf("[WS] Order snapshot: %d orders", len(snapshot.Orders))
+			for _, o := range snapshot.Orders {
+				log.Printf("[WS]   - %s: %s %s @ %s", o.ID, o.State, o.Side, o.Price.ValueOr("market"))
 			}
+			subscriptionMu.Lock()
+			*orderUpdateReceived = true
+			subscriptionMu.Unlock()
+		},
 
-			// Handle order update
-			// Doc: api-reference/websocket/private.mdx - Order Update Response
-			if msg.OrderSubscriptionUpdate != nil && msg.OrderSubscriptionUpdate.Execution != nil {
-				exec := msg.OrderSubscriptionUpdate.Execution
-				log.Printf("[WS] Order update: %s - %s", exec.Type, exec.ID)
-				if exec.Order != nil {
-					log.Printf("[WS]   Order state: %s", exec.Order.State)
-				}
+		// Handle order update
+		// Doc: api-reference/websocket/private.mdx - Order Update Response
+		OnOrderUpdate: func(update *models.OrderUpdate) {
+			if update.Execution == nil {
+				return
 			}
-
-			// Handle position update
-			// Doc: api-reference/websocket/private.mdx - Position Update Response
-			if msg.PositionSubscription != nil {
-				log.Printf("[WS] Position update: entry=%s", msg.PositionSubscription.EntryType)
-				if msg.PositionSubscription.AfterPosition != nil {
-					log.Printf("[WS]   Net position: %s", msg.PositionSubscription.AfterPosition.NetPosition)
-				}
-				subscriptionMu.Lock()
-				*positionUpdateReceived = true
-				subscriptionMu.Unlock()
+			exec := update.Execution
+			log.Printf("[WS] Order update: %s - %s", exec.Type, exec.ID)
+			if exec.Order != nil {
+				log.Printf("[WS]   Order state: %s", exec.Order.State)
 			}
+		},
 
-			// Handle balance snapshot
-			// Doc: api-reference/websocket/private.mdx - Balance Snapshot Response
-			if msg.AccountBalancesSnapshot != nil {
-				log.Printf("[WS] Balance snapshot: %d balances", len(msg.AccountBalancesSnapshot.Balances))
-				for _, b := range msg.AccountBalancesSnapshot.Balances {
-					log.Printf("[WS]   %s: $%.2f (buying power: $%.2f)", b.Currency, b.CurrentBalance, b.BuyingPower)
-				}
-				subscriptionMu.Lock()
-				*balanceSnapshotReceived = true
-				subscriptionMu.Unlock()
+		// Handle position update
+		// Doc: api-reference/websocket/private.mdx - Position Update Response
+		OnPositionUpdate: func(update *models.PositionUpdate) {
+			log.Printf("[WS] Position update: entry=%s", update.EntryType)
+			if update.AfterPosition != nil {
+				log.Printf("[WS]   Net position: %s", update.AfterPosition.NetPosition)
 			}
+			subscriptionMu.Lock()
+			*positionUpdateReceived = true
+			subscriptionMu.Unlock()
+		},
 
-			// Handle balance update
-			// Doc: api-reference/websocket/private.mdx - Balance Update Response
-			if msg.AccountBalancesUpdate != nil && msg.AccountBalancesUpdate.BalanceChange != nil {
-				change := msg.AccountBalancesUpdate.BalanceChange
-				log.Printf("[WS] Balance update: %s", change.Description)
-				if change.AfterBalance != nil {
-					log.Printf("[WS]   New balance: $%.2f", change.AfterBalance.CurrentBalance)
-				}
+		// Handle balance snapshot
+		// Doc: api-reference/websocket/private.mdx - Balance Snapshot Response
+		OnBalanceSnapshot: func(snapshot *models.BalanceSnapshot) {
+			log.Printf("[WS] Balance snapshot: %d balances", len(snapshot.Balances))
+			for _, b := range snapshot.Balances {
+				log.Printf("[WS]   %s: %.2f (buying power: %.2f)", b.Currency, b.CurrentBalance, b.BuyingPower)
 			}
+			subscriptionMu.Lock()
+			*balanceSnapshotReceived = true
+			subscriptionMu.Unlock()
+		},
 
-			// Handle market data
-			// Doc: api-reference/websocket/markets.mdx - Market Data Response
-			if msg.MarketData != nil {
-				md := msg.MarketData
-				summary := fmt.Sprintf("%s: %d bids, %d offers, state=%s",
-					md.MarketSlug, len(md.Bids), len(md.Offers), md.State)
-				log.Printf("[WS] Market data: %s", summary)
-
-				mu.Lock()
-				*marketData = append(*marketData, summary)
-				mu.Unlock()
-
-				// Print top of book
-				if len(md.Bids) > 0 {
-					log.Printf("[WS]   Best bid: %s @ %s", md.Bids[0].Qty, md.Bids[0].Px.Value)
-				}
-				if len(md.Offers) > 0 {
-					log.Printf("[WS]   Best ask: %s @ %s", md.Offers[0].Qty, md.Offers[0].Px.Value)
-				}
+		// Handle balance update
+		// Doc: api-reference/websocket/private.mdx - Balance Update Response
+		OnBalanceUpdate: func(update *models.BalanceUpdate) {
+			if update.BalanceChange == nil {
+				return
 			}
-
-			// Handle market data lite
-			// Doc: api-reference/websocket/markets.mdx - Market Data Lite Response
-			if msg.MarketDataLite != nil {
-				mdl := msg.MarketDataLite
-				summary := fmt.Sprintf("%s: bid=%s ask=%s", mdl.MarketSlug,
-					safeAmountValue(mdl.BestBid), safeAmountValue(mdl.BestAsk))
-				log.Printf("[WS] Market data lite: %s", summary)
-
-				mu.Lock()
-				*marketData = append(*marketData, summary)
-				mu.Unlock()
+			change := update.BalanceChange
+			log.Printf("[WS] Balance update: %s", change.Description)
+			if change.AfterBalance != nil {
+				log.Printf("[WS]   New balance: %.2f %s", change.AfterBalance.CurrentBalance, change.AfterBalance.Currency)
 			}
+		},
 
-			// Handle trade
-			// Doc: api-reference/websocket/markets.mdx - Trade Response
-			if msg.Trade != nil {
-				t := msg.Trade
-				summary := fmt.Sprintf("%s: trade @ %s qty=%s at %s",
-					t.MarketSlug, t.Price.Value, t.Quantity.Value, t.TradeTime)
-				log.Printf("[WS] Trade: %s", summary)
-
-				mu.Lock()
-				*marketData = append(*marketData, summary)
-				mu.Unlock()
+		// Handle market data
+		// Doc: api-reference/websocket/markets.mdx - Market Data Response
+		OnMarketData: func(md *models.MarketDataUpdate) {
+			summary := md.Summary()
+			log.Printf("[WS] Market data: %s", summary)
+			recordMarketData(summary)
+
+			// Print top of book
+			if len(md.Bids) > 0 {
+				log.Printf("[WS]   Best bid: %s @ %s", md.Bids[0].Qty, md.Bids[0].Px.ValueOr("N/A"))
 			}
-		}
+			if len(md.Offers) > 0 {
+				log.Printf("[WS]   Best ask: %s @ %s", md.Offers[0].Qty, md.Offers[0].Px.ValueOr("N/A"))
+			}
+		},
+
+		// Handle market data lite
+		// Doc: api-reference/websocket/markets.mdx - Market Data Lite Response
+		OnMarketDataLite: func(mdl *models.MarketDataLiteUpdate) {
+			summary := mdl.Summary()
+			log.Printf("[WS] Market data lite: %s", summary)
+			recordMarketData(summary)
+		},
+
+		// Handle trade
+		// Doc: api-reference/websocket/markets.mdx - Trade Response
+		OnTrade: func(t *models.TradeUpdate) {
+			summary := t.Summary()
+			log.Printf("[WS] Trade: %s", summary)
+			recordMarketData(summary)
+		},
 	}
 }
 
@@ -485,11 +507,3 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
-
-// safeAmountValue safely extracts value from Amount pointer.
-func safeAmountValue(a *models.Amount) string {
-	if a == nil {
-		return "N/A"
-	}
-	return a.Value
-}
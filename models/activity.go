@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ActivityKind is the typed form of Activity.Type.
+type ActivityKind int
+
+const (
+	ActivityKindUnknown ActivityKind = iota
+	ActivityKindTrade
+	ActivityKindPositionResolution
+	ActivityKindAccountBalanceChange
+)
+
+// String returns a lowercase label for the kind.
+func (k ActivityKind) String() string {
+	switch k {
+	case ActivityKindTrade:
+		return "trade"
+	case ActivityKindPositionResolution:
+		return "position resolution"
+	case ActivityKindAccountBalanceChange:
+		return "account balance change"
+	}
+	return "unknown"
+}
+
+// Kind returns the activity's kind from its Type, or from the payload it
+// carries when Type is empty or unrecognized.
+// Doc: api-reference/portfolio/overview.mdx - Activity Types
+func (a *Activity) Kind() ActivityKind {
+	switch a.Type {
+	case ActivityTypeTrade:
+		return ActivityKindTrade
+	case ActivityTypePositionResolution:
+		return ActivityKindPositionResolution
+	case ActivityTypeAccountBalanceChange:
+		return ActivityKindAccountBalanceChange
+	}
+	switch {
+	case a.Trade != nil:
+		return ActivityKindTrade
+	case a.PositionResolution != nil:
+		return ActivityKindPositionResolution
+	case a.AccountBalanceChange != nil:
+		return ActivityKindAccountBalanceChange
+	}
+	return ActivityKindUnknown
+}
+
+// AsTrade returns the trade payload of a trade activity.
+func (a *Activity) AsTrade() (*Trade, bool) {
+	if a.Kind() != ActivityKindTrade || a.Trade == nil {
+		return nil, false
+	}
+	return a.Trade, true
+}
+
+// AsPositionResolution returns the payload of a position resolution
+// activity.
+func (a *Activity) AsPositionResolution() (*PositionResolution, bool) {
+	if a.Kind() != ActivityKindPositionResolution || a.PositionResolution == nil {
+		return nil, false
+	}
+	return a.PositionResolution, true
+}
+
+// AsAccountBalanceChange returns the payload of an account balance change
+// activity.
+func (a *Activity) AsAccountBalanceChange() (*AccountBalanceChange, bool) {
+	if a.Kind() != ActivityKindAccountBalanceChange || a.AccountBalanceChange == nil {
+		return nil, false
+	}
+	return a.AccountBalanceChange, true
+}
+
+// ID returns the identifier of the activity's payload: the trade ID, the
+// balance change's transaction ID, or the trade ID of a position
+// resolution.
+func (a *Activity) ID() string {
+	if t, ok := a.AsTrade(); ok {
+		return t.ID
+	}
+	if r, ok := a.AsPositionResolution(); ok {
+		return r.TradeID
+	}
+	if c, ok := a.AsAccountBalanceChange(); ok {
+		return c.TransactionID
+	}
+	return ""
+}
+
+// MarketSlug returns the market a trade or position resolution happened in,
+// and "" for account balance changes.
+func (a *Activity) MarketSlug() string {
+	if t, ok := a.AsTrade(); ok {
+		return t.MarketSlug
+	}
+	if r, ok := a.AsPositionResolution(); ok {
+		return r.MarketSlug
+	}
+	return ""
+}
+
+// Time returns when the activity happened: a trade's CreateTime, a
+// resolution's UpdateTime, or a balance change's CreateTime, falling back
+// to its UpdateTime.
+func (a *Activity) Time() (time.Time, error) {
+	if t, ok := a.AsTrade(); ok {
+		return t.CreateTimeParsed()
+	}
+	if r, ok := a.AsPositionResolution(); ok {
+		return r.UpdateTimeParsed()
+	}
+	if c, ok := a.AsAccountBalanceChange(); ok {
+		if c.CreateTime != "" {
+			return c.CreateTimeParsed()
+		}
+		return c.UpdateTimeParsed()
+	}
+	return time.Time{}, fmt.Errorf("activity of type %q has no payload", a.Type)
+}
+
+// LedgerEntryType maps the activity to the LedgerEntryType* constant used
+// on the private streams: trades are order executions, resolutions are
+// resolutions, and balance changes are deposits, or withdrawals when their
+// amount is negative. It returns "" for unknown activities.
+//
+// Note: the docs do not give the sign of AccountBalanceChange.Amount;
+// withdrawals are taken to be negative. Commissions have no activity type.
+// Doc: api-reference/websocket/private.mdx - Ledger Entry Types
+func (a *Activity) LedgerEntryType() string {
+	switch a.Kind() {
+	case ActivityKindTrade:
+		return LedgerEntryTypeOrderExecution
+	case ActivityKindPositionResolution:
+		return LedgerEntryTypeResolution
+	case ActivityKindAccountBalanceChange:
+		if c := a.AccountBalanceChange; c != nil && c.Amount != nil {
+			if amount, err := c.Amount.Rat(); err == nil && amount.Sign() < 0 {
+				return LedgerEntryTypeWithdrawal
+			}
+		}
+		return LedgerEntryTypeDeposit
+	}
+	return ""
+}
+
+// amountRat returns a's value, treating a nil amount as zero.
+func amountRat(a *Amount) (*big.Rat, error) {
+	if a == nil {
+		return new(big.Rat), nil
+	}
+	return a.Rat()
+}
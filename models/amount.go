@@ -0,0 +1,181 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NewAmountFromFloat builds an Amount with f formatted as a plain decimal
+// string with exactly decimals digits after the point, e.g.
+// NewAmountFromFloat(0.1+0.2, 2, "USD") has value "0.30". Use it instead of
+// hand-written strings to avoid typos and float artifacts in prices.
+func NewAmountFromFloat(f float64, decimals int, currency string) (*Amount, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("invalid amount %v", f)
+	}
+	if decimals < 0 {
+		return nil, fmt.Errorf("invalid decimals %d: must not be negative", decimals)
+	}
+	return newAmount(strconv.FormatFloat(f, 'f', decimals, 64), currency)
+}
+
+// NewAmountFromRat builds an Amount with r rounded to decimals digits after
+// the point (halves away from zero).
+func NewAmountFromRat(r *big.Rat, decimals int, currency string) (*Amount, error) {
+	if r == nil {
+		return nil, fmt.Errorf("amount is nil")
+	}
+	if decimals < 0 {
+		return nil, fmt.Errorf("invalid decimals %d: must not be negative", decimals)
+	}
+	return newAmount(r.FloatString(decimals), currency)
+}
+
+// newAmount checks that value round-trips through ParseDecimal.
+func newAmount(value, currency string) (*Amount, error) {
+	if _, err := ParseDecimal(value); err != nil {
+		return nil, err
+	}
+	return &Amount{Value: value, Currency: currency}, nil
+}
+
+// ValueOr returns the amount's value, or def if the amount is nil. Many
+// responses omit optional prices (e.g. market orders have no Price), so use
+// this instead of dereferencing *Amount fields directly.
+func (a *Amount) ValueOr(def string) string {
+	if a == nil {
+		return def
+	}
+	return a.Value
+}
+
+// UnmarshalJSON decodes an Amount whose value is either a JSON string
+// ("0.55") or a JSON number (0.55). Numbers are normalized to a plain decimal
+// string without exponent, so Value always has the documented string form.
+// Schema: api-reference/oapi-schemas/orders-schema.json - Amount
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value    json.RawMessage `json:"value"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	value, err := decodeDecimal(raw.Value)
+	if err != nil {
+		return fmt.Errorf("invalid amount value: %w", err)
+	}
+	a.Value = value
+	a.Currency = raw.Currency
+	return nil
+}
+
+// decodeDecimal returns the decimal string held by a JSON string or number.
+// Absent and null values decode as "".
+func decodeDecimal(data json.RawMessage) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+	if data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	s := n.String()
+	if strings.ContainsAny(s, "eE") {
+		f, err := n.Float64()
+		if err != nil {
+			return "", err
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return s, nil
+}
+
+// currencySymbols maps currency codes to display symbols. Codes without a
+// symbol are rendered as a suffix, e.g. "12.50 USDC".
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+// Format renders the amount for display with the given number of decimal
+// places, e.g. "$0.55" or "12.50 USDC". A nil amount renders as "N/A"; a
+// value that is not a valid decimal is shown unchanged.
+func (a *Amount) Format(decimals int) string {
+	if a == nil {
+		return "N/A"
+	}
+	value := a.Value
+	if r, err := ParseDecimal(a.Value); err == nil {
+		value = r.FloatString(decimals)
+	}
+	return withCurrency(value, a.Currency)
+}
+
+// FormatAtTick renders the amount rounded to the nearest multiple of tickSize
+// (e.g. "0.01") using the tick's precision, so displayed prices match the
+// increments the market accepts. Falls back to Format(2) on an invalid tick.
+func (a *Amount) FormatAtTick(tickSize string) string {
+	if a == nil {
+		return "N/A"
+	}
+	tick, err := ParseDecimal(tickSize)
+	if err != nil || tick.Sign() <= 0 {
+		return a.Format(2)
+	}
+	r, err := ParseDecimal(a.Value)
+	if err != nil {
+		return withCurrency(a.Value, a.Currency)
+	}
+	return withCurrency(roundToTick(r, tick).FloatString(decimalPlaces(tickSize)), a.Currency)
+}
+
+// withCurrency attaches a currency symbol prefix or code suffix to value.
+func withCurrency(value, currency string) string {
+	if currency == "" {
+		return value
+	}
+	if symbol, ok := currencySymbols[currency]; ok {
+		if strings.HasPrefix(value, "-") {
+			return "-" + symbol + value[1:]
+		}
+		return symbol + value
+	}
+	return value + " " + currency
+}
+
+// roundToTick rounds r to the nearest multiple of tick, halves away from zero.
+func roundToTick(r, tick *big.Rat) *big.Rat {
+	q := new(big.Rat).Quo(r, tick)
+	n := new(big.Int).Quo(q.Num(), q.Denom())
+	rem := new(big.Rat).Sub(q, new(big.Rat).SetInt(n))
+	half := big.NewRat(1, 2)
+	if rem.Cmp(half) >= 0 {
+		n.Add(n, big.NewInt(1))
+	} else if rem.Cmp(new(big.Rat).Neg(half)) <= 0 {
+		n.Sub(n, big.NewInt(1))
+	}
+	return new(big.Rat).Mul(new(big.Rat).SetInt(n), tick)
+}
+
+// decimalPlaces returns the number of digits after the decimal point in s.
+func decimalPlaces(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Amount
+		wantErr bool
+	}{
+		{"string", `{"value":"0.55","currency":"USD"}`, Amount{Value: "0.55", Currency: "USD"}, false},
+		{"number", `{"value":0.55,"currency":"USD"}`, Amount{Value: "0.55", Currency: "USD"}, false},
+		{"integer", `{"value":12,"currency":"USD"}`, Amount{Value: "12", Currency: "USD"}, false},
+		{"exponent", `{"value":1.5e-3,"currency":"USD"}`, Amount{Value: "0.0015", Currency: "USD"}, false},
+		{"null", `{"value":null,"currency":"USD"}`, Amount{Currency: "USD"}, false},
+		{"absent", `{"currency":"USD"}`, Amount{Currency: "USD"}, false},
+		{"object", `{"value":{},"currency":"USD"}`, Amount{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import "sort"
+
+// DefaultCurrency is assumed for amounts that carry no currency code.
+// Note: the docs do not say which currency an empty code means; every
+// example uses USD.
+// Doc: api-reference/account/overview.mdx - Example Response
+const DefaultCurrency = "USD"
+
+// BalanceByCurrency returns the balance in balances for currency, matching
+// an empty currency as DefaultCurrency. It reports false when the account
+// holds no balance in that currency; callers should not fall back to
+// another currency's balance.
+func BalanceByCurrency(balances []Balance, currency string) (Balance, bool) {
+	currency = CurrencyOrDefault(currency)
+	for _, b := range balances {
+		if CurrencyOrDefault(b.Currency) == currency {
+			return b, true
+		}
+	}
+	return Balance{}, false
+}
+
+// BalanceByCurrency returns the balance for currency; see BalanceByCurrency.
+func (r *GetBalancesResponse) BalanceByCurrency(currency string) (Balance, bool) {
+	return BalanceByCurrency(r.Balances, currency)
+}
+
+// Currencies returns the sorted currency codes of the balances.
+func (r *GetBalancesResponse) Currencies() []string {
+	currencies := make([]string, 0, len(r.Balances))
+	for _, b := range r.Balances {
+		currencies = append(currencies, CurrencyOrDefault(b.Currency))
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// PendingWithdrawalTotal returns the sum of the balance's pending
+// withdrawals, which are in the balance's currency.
+// Doc: api-reference/account/overview.mdx - Pending Withdrawals
+func (b *Balance) PendingWithdrawalTotal() float64 {
+	var total float64
+	for _, w := range b.PendingWithdrawals {
+		total += w.Balance
+	}
+	return total
+}
+
+// CurrencyTotals are the AccountSummary totals for one currency.
+type CurrencyTotals struct {
+	Currency           string  `json:"currency"`
+	TotalBalance       float64 `json:"totalBalance"`       // CurrentBalance
+	BuyingPower        float64 `json:"buyingPower"`        // BuyingPower
+	OpenOrderExposure  float64 `json:"openOrderExposure"`  // Balance.OpenOrders
+	PendingWithdrawals float64 `json:"pendingWithdrawals"` // Balance.PendingWithdrawalTotal
+	PositionsValue     float64 `json:"positionsValue"`     // Sum of position CashValue in this currency
+	TotalEquity        float64 `json:"totalEquity"`        // TotalBalance + PositionsValue
+}
+
+// CurrencyOrDefault returns currency, or DefaultCurrency when it is empty.
+func CurrencyOrDefault(currency string) string {
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBalanceByCurrency(t *testing.T) {
+	resp := &GetBalancesResponse{Balances: []Balance{
+		{Currency: "EUR", BuyingPower: 5},
+		{Currency: "", BuyingPower: 100},
+	}}
+	tests := []struct {
+		currency        string
+		wantBuyingPower float64
+		wantOK          bool
+	}{
+		{"EUR", 5, true},
+		{"USD", 100, true},
+		{"", 100, true},
+		{"GBP", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := resp.BalanceByCurrency(tt.currency)
+		if ok != tt.wantOK || got.BuyingPower != tt.wantBuyingPower {
+			t.Errorf("BalanceByCurrency(%q) = %v, %v, want buying power %v, %v",
+				tt.currency, got.BuyingPower, ok, tt.wantBuyingPower, tt.wantOK)
+		}
+	}
+	if got, want := resp.Currencies(), []string{"EUR", "USD"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Currencies() = %v, want %v", got, want)
+	}
+}
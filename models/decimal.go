@@ -0,0 +1,197 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ParseDecimal parses a plain decimal string such as "12.5" or "-3" into an
+// exact rational. An empty string is treated as zero, matching omitted
+// quantity fields. Anything else big.Rat would accept, such as fractions
+// ("1/2"), exponents ("5e-1"), hex floats, a leading "+", or a point
+// without digits on both sides (".5", "5."), is rejected.
+func ParseDecimal(s string) (*big.Rat, error) {
+	if s == "" {
+		return new(big.Rat), nil
+	}
+	if !isPlainDecimal(s) {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// isPlainDecimal reports whether s is an optional "-" followed by digits,
+// optionally followed by "." and more digits.
+func isPlainDecimal(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	return allDigits(whole) && (!hasPoint || allDigits(frac))
+}
+
+// allDigits reports whether s is a non-empty run of ASCII digits.
+func allDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFloat parses a decimal string as float64, treating empty as zero.
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal %q: %w", s, err)
+	}
+	return f, nil
+}
+
+// Float returns the amount value as float64. Use Rat for exact arithmetic.
+func (a *Amount) Float() (float64, error) {
+	if a == nil {
+		return 0, fmt.Errorf("amount is nil")
+	}
+	return parseFloat(a.Value)
+}
+
+// Rat returns the amount value as an exact rational.
+func (a *Amount) Rat() (*big.Rat, error) {
+	if a == nil {
+		return nil, fmt.Errorf("amount is nil")
+	}
+	return ParseDecimal(a.Value)
+}
+
+// NetPositionFloat returns NetPosition as float64.
+func (p *UserPosition) NetPositionFloat() (float64, error) {
+	return parseFloat(p.NetPosition)
+}
+
+// NetPositionRat returns NetPosition as an exact rational.
+func (p *UserPosition) NetPositionRat() (*big.Rat, error) {
+	return ParseDecimal(p.NetPosition)
+}
+
+// QtyBoughtFloat returns QtyBought as float64.
+func (p *UserPosition) QtyBoughtFloat() (float64, error) {
+	return parseFloat(p.QtyBought)
+}
+
+// QtyBoughtRat returns QtyBought as an exact rational.
+func (p *UserPosition) QtyBoughtRat() (*big.Rat, error) {
+	return ParseDecimal(p.QtyBought)
+}
+
+// QtySoldFloat returns QtySold as float64.
+func (p *UserPosition) QtySoldFloat() (float64, error) {
+	return parseFloat(p.QtySold)
+}
+
+// QtySoldRat returns QtySold as an exact rational.
+func (p *UserPosition) QtySoldRat() (*big.Rat, error) {
+	return ParseDecimal(p.QtySold)
+}
+
+// QtyAvailableFloat returns QtyAvailable as float64.
+func (p *UserPosition) QtyAvailableFloat() (float64, error) {
+	return parseFloat(p.QtyAvailable)
+}
+
+// QtyAvailableRat returns QtyAvailable as an exact rational.
+func (p *UserPosition) QtyAvailableRat() (*big.Rat, error) {
+	return ParseDecimal(p.QtyAvailable)
+}
+
+// NetTraded returns QtyBought - QtySold computed exactly.
+func (p *UserPosition) NetTraded() (*big.Rat, error) {
+	bought, err := p.QtyBoughtRat()
+	if err != nil {
+		return nil, fmt.Errorf("qtyBought: %w", err)
+	}
+	sold, err := p.QtySoldRat()
+	if err != nil {
+		return nil, fmt.Errorf("qtySold: %w", err)
+	}
+	return new(big.Rat).Sub(bought, sold), nil
+}
+
+// QtyFloat returns the trade quantity as float64.
+func (t *Trade) QtyFloat() (float64, error) {
+	return parseFloat(t.Qty)
+}
+
+// QtyRat returns the trade quantity as an exact rational.
+func (t *Trade) QtyRat() (*big.Rat, error) {
+	return ParseDecimal(t.Qty)
+}
+
+// errNoStats is returned by MarketStats accessors on a nil receiver, since
+// market data updates omit stats when unchanged.
+var errNoStats = fmt.Errorf("market stats not set")
+
+// LastTradeFloat returns LastTradePx as float64. Safe on a nil receiver.
+func (s *MarketStats) LastTradeFloat() (float64, error) {
+	if s == nil {
+		return 0, errNoStats
+	}
+	return s.LastTradePx.Float()
+}
+
+// HighFloat returns HighPx as float64. Safe on a nil receiver.
+func (s *MarketStats) HighFloat() (float64, error) {
+	if s == nil {
+		return 0, errNoStats
+	}
+	return s.HighPx.Float()
+}
+
+// LowFloat returns LowPx as float64. Safe on a nil receiver.
+func (s *MarketStats) LowFloat() (float64, error) {
+	if s == nil {
+		return 0, errNoStats
+	}
+	return s.LowPx.Float()
+}
+
+// SharesTradedInt returns SharesTraded as a whole number of shares. Safe on
+// a nil receiver.
+func (s *MarketStats) SharesTradedInt() (int64, error) {
+	if s == nil {
+		return 0, errNoStats
+	}
+	return parseShares(s.SharesTraded)
+}
+
+// OpenInterestInt returns OpenInterest as a whole number of shares. Safe on
+// a nil receiver.
+func (s *MarketStats) OpenInterestInt() (int64, error) {
+	if s == nil {
+		return 0, errNoStats
+	}
+	return parseShares(s.OpenInterest)
+}
+
+// parseShares parses a decimal share count that must be integral.
+func parseShares(v string) (int64, error) {
+	r, err := ParseDecimal(v)
+	if err != nil {
+		return 0, err
+	}
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("share count %q is not a whole number", v)
+	}
+	return r.Num().Int64(), nil
+}
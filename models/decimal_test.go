@@ -0,0 +1,43 @@
+package models
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string // RatString of the result; empty when an error is expected
+		wantErr bool
+	}{
+		{"12.5", "25/2", false},
+		{"0.55", "11/20", false},
+		{"-3", "-3", false},
+		{"0", "0", false},
+		{"", "0", false},
+		{".5", "", true},
+		{"5.", "", true},
+		{"1/2", "", true},
+		{"5e-1", "", true},
+		{"0x1p-1", "", true},
+		{"+0.5", "", true},
+		{"0,45", "", true},
+		{" 1", "", true},
+		{"-", "", true},
+		{"1.2.3", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDecimal(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if want, _ := new(big.Rat).SetString(tt.want); got.Cmp(want) != 0 {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.input, got.RatString(), tt.want)
+		}
+	}
+}
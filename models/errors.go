@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// APIErrorBody is the JSON error envelope returned with non-2xx responses.
+// Note: the envelope is not formally documented; fields are decoded
+// leniently and any of them may be empty.
+type APIErrorBody struct {
+	Code    ErrorCode         `json:"code,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Error   string            `json:"error,omitempty"` // Some gateways use "error" instead of "message"
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// ErrorCode is an error code that may be sent as a JSON string or number.
+type ErrorCode string
+
+// UnmarshalJSON accepts both "INVALID_ARGUMENT" and 3.
+func (c *ErrorCode) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*c = ErrorCode(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*c = ErrorCode(n.String())
+	return nil
+}
+
+// ParseAPIErrorBody decodes body as an error envelope. It reports false when
+// body is not a JSON object or carries none of the envelope fields.
+func ParseAPIErrorBody(body []byte) (*APIErrorBody, bool) {
+	trimmed := strings.TrimSpace(string(body))
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, false
+	}
+	var parsed APIErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, false
+	}
+	if parsed.Code == "" && parsed.Message == "" && parsed.Error == "" && len(parsed.Details) == 0 {
+		return nil, false
+	}
+	if parsed.Message == "" {
+		parsed.Message = parsed.Error
+	}
+	return &parsed, true
+}
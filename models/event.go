@@ -0,0 +1,143 @@
+package models
+
+import "fmt"
+
+// Event type labels returned by WSMessage.EventType. They are stable and
+// suitable as log fields or metric labels.
+const (
+	EventTypeError           = "error"
+	EventTypeHeartbeat       = "heartbeat"
+	EventTypeOrderSnapshot   = "order_snapshot"
+	EventTypeOrderUpdate     = "order_update"
+	EventTypePositionUpdate  = "position_update"
+	EventTypeBalanceSnapshot = "balance_snapshot"
+	EventTypeBalanceUpdate   = "balance_update"
+	EventTypeMarketData      = "market_data"
+	EventTypeMarketDataLite  = "market_data_lite"
+	EventTypeTrade           = "trade"
+	EventTypeUnknown         = "unknown"
+)
+
+// EventType returns a label for the payload the message carries, e.g.
+// "market_data" or "order_update". A message with an error is labelled
+// "error" regardless of its payload.
+func (m *WSMessage) EventType() string {
+	switch {
+	case m.Error != "":
+		return EventTypeError
+	case m.Heartbeat != nil:
+		return EventTypeHeartbeat
+	case m.OrderSubscriptionSnapshot != nil:
+		return EventTypeOrderSnapshot
+	case m.OrderSubscriptionUpdate != nil:
+		return EventTypeOrderUpdate
+	case m.PositionSubscription != nil:
+		return EventTypePositionUpdate
+	case m.AccountBalancesSnapshot != nil:
+		return EventTypeBalanceSnapshot
+	case m.AccountBalancesUpdate != nil:
+		return EventTypeBalanceUpdate
+	case m.MarketData != nil:
+		return EventTypeMarketData
+	case m.MarketDataLite != nil:
+		return EventTypeMarketDataLite
+	case m.Trade != nil:
+		return EventTypeTrade
+	}
+	return EventTypeUnknown
+}
+
+// Summary returns a one-line description of the message for logging, e.g.
+// "market_data will-it-rain: 5 bids, 4 offers, state=MARKET_STATE_OPEN".
+func (m *WSMessage) Summary() string {
+	label := m.EventType()
+	var detail string
+	switch label {
+	case EventTypeError:
+		detail = fmt.Sprintf("%s (requestId: %s)", m.Error, m.RequestID)
+	case EventTypeOrderSnapshot:
+		detail = m.OrderSubscriptionSnapshot.Summary()
+	case EventTypeOrderUpdate:
+		detail = m.OrderSubscriptionUpdate.Summary()
+	case EventTypePositionUpdate:
+		detail = m.PositionSubscription.Summary()
+	case EventTypeBalanceSnapshot:
+		detail = m.AccountBalancesSnapshot.Summary()
+	case EventTypeBalanceUpdate:
+		detail = m.AccountBalancesUpdate.Summary()
+	case EventTypeMarketData:
+		detail = m.MarketData.Summary()
+	case EventTypeMarketDataLite:
+		detail = m.MarketDataLite.Summary()
+	case EventTypeTrade:
+		detail = m.Trade.Summary()
+	}
+	if detail == "" {
+		return label
+	}
+	return label + " " + detail
+}
+
+// Summary returns e.g. "3 orders (complete)".
+func (s *OrderSnapshot) Summary() string {
+	if s.EOF {
+		return fmt.Sprintf("%d orders (complete)", len(s.Orders))
+	}
+	return fmt.Sprintf("%d orders", len(s.Orders))
+}
+
+// Summary returns e.g. "EXECUTION_TYPE_FILL abc123 state=ORDER_STATE_FILLED".
+func (u *OrderUpdate) Summary() string {
+	exec := u.Execution
+	if exec == nil {
+		return "no execution"
+	}
+	if exec.Order == nil {
+		return fmt.Sprintf("%s %s", exec.Type, exec.ID)
+	}
+	return fmt.Sprintf("%s %s state=%s", exec.Type, exec.ID, exec.Order.State)
+}
+
+// Summary returns e.g. "entry=LEDGER_ENTRY_TYPE_ORDER_EXECUTION net=10".
+func (u *PositionUpdate) Summary() string {
+	if u.AfterPosition == nil {
+		return "entry=" + u.EntryType
+	}
+	return fmt.Sprintf("entry=%s net=%s", u.EntryType, u.AfterPosition.NetPosition)
+}
+
+// Summary returns e.g. "1 balances".
+func (s *BalanceSnapshot) Summary() string {
+	return fmt.Sprintf("%d balances", len(s.Balances))
+}
+
+// Summary returns the change description and the new balance, if known.
+func (u *BalanceUpdate) Summary() string {
+	change := u.BalanceChange
+	if change == nil {
+		return "no change"
+	}
+	if change.AfterBalance == nil {
+		return change.Description
+	}
+	return fmt.Sprintf("%s balance=%.2f %s", change.Description,
+		change.AfterBalance.CurrentBalance, change.AfterBalance.Currency)
+}
+
+// Summary returns e.g. "will-it-rain: 5 bids, 4 offers, state=MARKET_STATE_OPEN".
+func (md *MarketDataUpdate) Summary() string {
+	return fmt.Sprintf("%s: %d bids, %d offers, state=%s",
+		md.MarketSlug, len(md.Bids), len(md.Offers), md.State)
+}
+
+// Summary returns e.g. "will-it-rain: bid=0.55 ask=0.57".
+func (md *MarketDataLiteUpdate) Summary() string {
+	return fmt.Sprintf("%s: bid=%s ask=%s", md.MarketSlug,
+		md.BestBid.ValueOr("N/A"), md.BestAsk.ValueOr("N/A"))
+}
+
+// Summary returns e.g. "will-it-rain: trade @ 0.56 qty=10 at 2024-01-01T00:00:00Z".
+func (t *TradeUpdate) Summary() string {
+	return fmt.Sprintf("%s: trade @ %s qty=%s at %s",
+		t.MarketSlug, t.Price.ValueOr("N/A"), t.Quantity.ValueOr("N/A"), t.TradeTime)
+}
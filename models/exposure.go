@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// MarketExposure is one position's share of an EventExposure.
+type MarketExposure struct {
+	MarketSlug string
+	Outcome    string   // MarketMetadata.Outcome, if known
+	Net        *big.Rat // NetPosition; negative when short
+	Cost       *big.Rat // Signed cost; zero when not reported
+	Price      *big.Rat // Current price per share; nil when none was given
+	Value      *big.Rat // Net * Price; nil without a price
+	PnL        *big.Rat // Realized + Value - Cost; nil without a price
+}
+
+// EventExposure aggregates the positions held in the markets of one event,
+// e.g. the outcomes of one game, whose prices move together.
+type EventExposure struct {
+	EventSlug string // The market slug for a market outside any event
+	Markets   []MarketExposure
+
+	Net   *big.Rat // Sum of net positions
+	Gross *big.Rat // Sum of absolute net positions
+	Cost  *big.Rat // Sum of costs
+	Value *big.Rat // Sum of Value over priced markets
+	PnL   *big.Rat // Sum of PnL over priced markets
+
+	// Unpriced lists markets without a price, left out of Value and PnL.
+	Unpriced []string
+}
+
+// EventExposures groups positions by MarketMetadata.EventSlug and sums each
+// event's exposure, marking positions to prices (market slug to current
+// price per share, e.g. the mid). Markets without an event form a group of
+// their own. Flat positions are skipped. Events are sorted by slug.
+func EventExposures(positions map[string]UserPosition, prices map[string]*big.Rat) ([]EventExposure, error) {
+	events := make(map[string]*EventExposure)
+	for slug, p := range positions {
+		m, err := marketExposure(slug, &p, prices[slug])
+		if err != nil {
+			return nil, err
+		}
+		if m.Net.Sign() == 0 {
+			continue
+		}
+
+		event := slug
+		if p.MarketMetadata != nil && p.MarketMetadata.EventSlug != "" {
+			event = p.MarketMetadata.EventSlug
+		}
+		e, ok := events[event]
+		if !ok {
+			e = &EventExposure{
+				EventSlug: event,
+				Net:       new(big.Rat),
+				Gross:     new(big.Rat),
+				Cost:      new(big.Rat),
+				Value:     new(big.Rat),
+				PnL:       new(big.Rat),
+			}
+			events[event] = e
+		}
+		e.add(m)
+	}
+
+	result := make([]EventExposure, 0, len(events))
+	for _, e := range events {
+		sort.Slice(e.Markets, func(i, j int) bool { return e.Markets[i].MarketSlug < e.Markets[j].MarketSlug })
+		sort.Strings(e.Unpriced)
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EventSlug < result[j].EventSlug })
+	return result, nil
+}
+
+// add folds one market into the event's totals.
+func (e *EventExposure) add(m MarketExposure) {
+	e.Markets = append(e.Markets, m)
+	e.Net.Add(e.Net, m.Net)
+	e.Gross.Add(e.Gross, new(big.Rat).Abs(m.Net))
+	e.Cost.Add(e.Cost, m.Cost)
+	if m.Price == nil {
+		e.Unpriced = append(e.Unpriced, m.MarketSlug)
+		return
+	}
+	e.Value.Add(e.Value, m.Value)
+	e.PnL.Add(e.PnL, m.PnL)
+}
+
+// marketExposure values one position at price, which may be nil.
+func marketExposure(slug string, p *UserPosition, price *big.Rat) (MarketExposure, error) {
+	m := MarketExposure{MarketSlug: slug, Cost: new(big.Rat)}
+	if p.MarketMetadata != nil {
+		m.Outcome = p.MarketMetadata.Outcome
+	}
+	net, err := netPosition(p)
+	if err != nil {
+		return m, fmt.Errorf("%s net position: %w", slug, err)
+	}
+	m.Net = net
+	if p.Cost != nil {
+		if m.Cost, err = p.Cost.Rat(); err != nil {
+			return m, fmt.Errorf("%s cost: %w", slug, err)
+		}
+	}
+	if price == nil {
+		return m, nil
+	}
+
+	realized := new(big.Rat)
+	if p.Realized != nil {
+		if realized, err = p.Realized.Rat(); err != nil {
+			return m, fmt.Errorf("%s realized: %w", slug, err)
+		}
+	}
+	m.Price = price
+	m.Value = new(big.Rat).Mul(net, price)
+	m.PnL = new(big.Rat).Add(realized, m.Value)
+	m.PnL.Sub(m.PnL, m.Cost)
+	return m, nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FillTracker accumulates an order's fill executions into a volume-weighted
+// average fill price, computed exactly from Execution.LastPx and
+// Execution.LastShares.
+type FillTracker struct {
+	Qty      *big.Rat // Shares filled
+	Notional *big.Rat // Sum of LastPx * LastShares
+
+	seen map[string]bool // execution IDs already counted
+}
+
+// NewFillTracker creates an empty tracker.
+func NewFillTracker() *FillTracker {
+	return &FillTracker{
+		Qty:      new(big.Rat),
+		Notional: new(big.Rat),
+		seen:     make(map[string]bool),
+	}
+}
+
+// Add counts e if it is a fill or partial fill, reporting whether it was
+// counted. Executions already counted (by ID) are ignored, so a replayed
+// update is not double counted.
+func (t *FillTracker) Add(e *Execution) (bool, error) {
+	if e == nil || (e.Type != ExecutionTypeFill && e.Type != ExecutionTypePartialFill) {
+		return false, nil
+	}
+	if e.ID != "" && t.seen[e.ID] {
+		return false, nil
+	}
+	px, err := e.LastPx.Rat()
+	if err != nil {
+		return false, fmt.Errorf("execution %s: invalid lastPx: %w", e.ID, err)
+	}
+	qty, err := ParseDecimal(e.LastShares)
+	if err != nil {
+		return false, fmt.Errorf("execution %s: invalid lastShares: %w", e.ID, err)
+	}
+	if e.ID != "" {
+		t.seen[e.ID] = true
+	}
+	t.Qty.Add(t.Qty, qty)
+	t.Notional.Add(t.Notional, new(big.Rat).Mul(px, qty))
+	return true, nil
+}
+
+// AveragePrice returns Notional / Qty, or false if nothing has filled.
+func (t *FillTracker) AveragePrice() (*big.Rat, bool) {
+	if t.Qty.Sign() == 0 {
+		return nil, false
+	}
+	return new(big.Rat).Quo(t.Notional, t.Qty), true
+}
+
+// AverageFillPrice returns the server-reported average fill price (AvgPx)
+// as an exact rational. It fails for an order with no fills.
+func (o *Order) AverageFillPrice() (*big.Rat, error) {
+	if o.CumQuantity <= 0 {
+		return nil, fmt.Errorf("order %s has no fills", o.ID)
+	}
+	if o.AvgPx == nil {
+		return nil, fmt.Errorf("order %s has no average price", o.ID)
+	}
+	return o.AvgPx.Rat()
+}
+
+// AvgPxMatches reports whether avg agrees with the order's AvgPx to the
+// precision AvgPx is reported in, i.e. within half a unit of its last
+// decimal place. It reports true when the order has no AvgPx to compare.
+func (o *Order) AvgPxMatches(avg *big.Rat) bool {
+	server, err := o.AverageFillPrice()
+	if err != nil {
+		return true
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimalPlaces(o.AvgPx.Value))), nil)
+	tolerance := new(big.Rat).SetFrac(big.NewInt(1), unit.Mul(unit, big.NewInt(2)))
+	diff := new(big.Rat).Sub(avg, server)
+	return diff.Abs(diff).Cmp(tolerance) <= 0
+}
@@ -0,0 +1,19 @@
+package models
+
+// PriceHistoryBar is one OHLC bar from the price history endpoint.
+// Note: the endpoint and its response shape are not documented; the field
+// names follow the conventions of the other market responses.
+type PriceHistoryBar struct {
+	StartTime string  `json:"startTime"`
+	Open      *Amount `json:"open"`
+	High      *Amount `json:"high"`
+	Low       *Amount `json:"low"`
+	Close     *Amount `json:"close"`
+	Volume    string  `json:"volume,omitempty"` // Shares traded
+	Trades    int     `json:"trades,omitempty"`
+}
+
+// GetPriceHistoryResponse is the response from the price history endpoint.
+type GetPriceHistoryResponse struct {
+	Bars []PriceHistoryBar `json:"bars"`
+}
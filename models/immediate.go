@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FillOutcome classifies how much of an order executed.
+type FillOutcome int
+
+const (
+	FillOutcomeNone    FillOutcome = iota // Nothing filled
+	FillOutcomePartial                    // Some but not all filled
+	FillOutcomeFull                       // Whole quantity filled
+)
+
+// String returns a lowercase label for the outcome.
+func (o FillOutcome) String() string {
+	switch o {
+	case FillOutcomePartial:
+		return "partially filled"
+	case FillOutcomeFull:
+		return "filled"
+	}
+	return "not filled"
+}
+
+// ImmediateResult is the outcome of an IOC or FOK order, read from the
+// executions in its CreateOrderResponse.
+type ImmediateResult struct {
+	OrderID   string
+	Requested float64  // Order quantity; zero when no execution reports the order
+	Filled    *big.Rat // Shares filled
+	AvgPx     *big.Rat // Volume-weighted fill price; nil if nothing filled
+	Outcome   FillOutcome
+	Canceled  bool // The unfilled remainder was canceled or expired
+	Rejected  bool // The order was rejected outright
+}
+
+// InterpretImmediate summarizes an immediate-or-cancel or fill-or-kill
+// order's response: how much filled, at what average price, and whether the
+// remainder was canceled.
+//
+// Note: executions are only included when the order was submitted with
+// SynchronousExecution; a response without any fails.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderResponse
+func (r *CreateOrderResponse) InterpretImmediate() (*ImmediateResult, error) {
+	if len(r.Executions) == 0 {
+		return nil, fmt.Errorf("order %s response has no executions; submit with SynchronousExecution", r.ID)
+	}
+	result := &ImmediateResult{OrderID: r.ID}
+	fills := NewFillTracker()
+	var last *Order
+	for i := range r.Executions {
+		e := &r.Executions[i]
+		if _, err := fills.Add(e); err != nil {
+			return nil, err
+		}
+		switch e.Type {
+		case ExecutionTypeCanceled, ExecutionTypeExpired:
+			result.Canceled = true
+		case ExecutionTypeRejected:
+			result.Rejected = true
+		}
+		if e.Order != nil {
+			last = e.Order
+		}
+	}
+
+	result.Filled = fills.Qty
+	result.AvgPx, _ = fills.AveragePrice()
+	if last != nil {
+		result.Requested = last.Quantity
+	}
+
+	switch {
+	case fills.Qty.Sign() == 0:
+		result.Outcome = FillOutcomeNone
+	case last != nil && (last.State == OrderStateFilled || last.IsFullyFilled()):
+		result.Outcome = FillOutcomeFull
+	case last == nil && !result.Canceled:
+		// No order state to compare against; a fill without a cancel of
+		// the remainder means nothing was left over.
+		result.Outcome = FillOutcomeFull
+	default:
+		result.Outcome = FillOutcomePartial
+	}
+	return result, nil
+}
+
+// InterpretFOK is InterpretImmediate for a fill-or-kill order. It fails if
+// the order partially filled, which fill-or-kill rules out, or if it neither
+// filled nor was canceled or rejected.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderResponse
+func (r *CreateOrderResponse) InterpretFOK() (*ImmediateResult, error) {
+	result, err := r.InterpretImmediate()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case result.Outcome == FillOutcomePartial:
+		return result, fmt.Errorf("fill-or-kill order %s partially filled %s of %v",
+			r.ID, formatQty(result.Filled), result.Requested)
+	case result.Outcome == FillOutcomeNone && !result.Canceled && !result.Rejected:
+		return result, fmt.Errorf("fill-or-kill order %s neither filled nor was killed", r.ID)
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+const (
+	fullFillPayload = `{"id":"order-1","executions":[
+		{"id":"e1","type":"EXECUTION_TYPE_PARTIAL_FILL","lastShares":"6","lastPx":{"value":"0.50","currency":"USD"},
+		 "order":{"id":"order-1","quantity":10,"cumQuantity":6,"state":"ORDER_STATE_PARTIALLY_FILLED"}},
+		{"id":"e2","type":"EXECUTION_TYPE_FILL","lastShares":"4","lastPx":{"value":"0.60","currency":"USD"},
+		 "order":{"id":"order-1","quantity":10,"cumQuantity":10,"state":"ORDER_STATE_FILLED"}}]}`
+	partialFillPayload = `{"id":"order-1","executions":[
+		{"id":"e1","type":"EXECUTION_TYPE_PARTIAL_FILL","lastShares":"4","lastPx":{"value":"0.50","currency":"USD"},
+		 "order":{"id":"order-1","quantity":10,"cumQuantity":4,"state":"ORDER_STATE_PARTIALLY_FILLED"}},
+		{"id":"e2","type":"EXECUTION_TYPE_CANCELED",
+		 "order":{"id":"order-1","quantity":10,"cumQuantity":4,"state":"ORDER_STATE_CANCELED"}}]}`
+	killedPayload = `{"id":"order-1","executions":[
+		{"id":"e1","type":"EXECUTION_TYPE_CANCELED",
+		 "order":{"id":"order-1","quantity":10,"state":"ORDER_STATE_CANCELED"}}]}`
+	rejectedPayload = `{"id":"order-1","executions":[
+		{"id":"e1","type":"EXECUTION_TYPE_REJECTED","orderRejectReason":"insufficient funds"}]}`
+	restingPayload = `{"id":"order-1","executions":[
+		{"id":"e1","type":"EXECUTION_TYPE_REPLACE",
+		 "order":{"id":"order-1","quantity":10,"state":"ORDER_STATE_PENDING_NEW"}}]}`
+	noExecutionsPayload = `{"id":"order-1"}`
+)
+
+func TestInterpretImmediate(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      string
+		wantOutcome  FillOutcome
+		wantFilled   string
+		wantAvgPx    string // Empty for no fills
+		wantCanceled bool
+		wantRejected bool
+		wantErr      bool
+	}{
+		{name: "full fill", payload: fullFillPayload, wantOutcome: FillOutcomeFull, wantFilled: "10", wantAvgPx: "0.54"},
+		{name: "partial fill", payload: partialFillPayload, wantOutcome: FillOutcomePartial, wantFilled: "4", wantAvgPx: "0.5", wantCanceled: true},
+		{name: "nothing filled", payload: killedPayload, wantOutcome: FillOutcomeNone, wantFilled: "0", wantCanceled: true},
+		{name: "rejected", payload: rejectedPayload, wantOutcome: FillOutcomeNone, wantFilled: "0", wantRejected: true},
+		{name: "no executions", payload: noExecutionsPayload, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp CreateOrderResponse
+			if err := json.Unmarshal([]byte(tt.payload), &resp); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			got, err := resp.InterpretImmediate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InterpretImmediate error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %v, want %v", got.Outcome, tt.wantOutcome)
+			}
+			if want, _ := new(big.Rat).SetString(tt.wantFilled); got.Filled.Cmp(want) != 0 {
+				t.Errorf("Filled = %s, want %s", got.Filled.RatString(), tt.wantFilled)
+			}
+			switch {
+			case tt.wantAvgPx == "" && got.AvgPx != nil:
+				t.Errorf("AvgPx = %s, want nil", got.AvgPx.RatString())
+			case tt.wantAvgPx != "":
+				want, _ := new(big.Rat).SetString(tt.wantAvgPx)
+				if got.AvgPx == nil || got.AvgPx.Cmp(want) != 0 {
+					t.Errorf("AvgPx = %v, want %s", got.AvgPx, tt.wantAvgPx)
+				}
+			}
+			if got.Canceled != tt.wantCanceled || got.Rejected != tt.wantRejected {
+				t.Errorf("Canceled, Rejected = %v, %v, want %v, %v", got.Canceled, got.Rejected, tt.wantCanceled, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestInterpretFOK(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		wantOutcome FillOutcome
+		wantErr     bool
+	}{
+		{"filled", fullFillPayload, FillOutcomeFull, false},
+		{"killed", killedPayload, FillOutcomeNone, false},
+		{"rejected", rejectedPayload, FillOutcomeNone, false},
+		{"partially filled", partialFillPayload, FillOutcomePartial, true},
+		{"neither filled nor killed", restingPayload, FillOutcomeNone, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp CreateOrderResponse
+			if err := json.Unmarshal([]byte(tt.payload), &resp); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			got, err := resp.InterpretFOK()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InterpretFOK error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %v, want %v", got.Outcome, tt.wantOutcome)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LedgerEntry is one activity's effect on a LedgerBuilder's ledger.
+type LedgerEntry struct {
+	Time       time.Time
+	EntryType  string // LedgerEntryType* constant
+	ActivityID string
+	MarketSlug string // Empty for deposits and withdrawals
+
+	CashDelta *big.Rat // Change in cash; nil when the activity does not carry it
+	QtyDelta  *big.Rat // Change in MarketSlug's net position; nil for cash entries
+
+	Balance  *big.Rat // Running cash balance after the entry
+	Position *big.Rat // Running net position in MarketSlug after the entry; nil for cash entries
+}
+
+// LedgerStatement summarizes the ledger over a period.
+type LedgerStatement struct {
+	From, To       time.Time
+	OpeningBalance *big.Rat
+	ClosingBalance *big.Rat
+	Entries        []LedgerEntry
+
+	Deposits    *big.Rat // Sum of deposit CashDelta
+	Withdrawals *big.Rat // Sum of withdrawal CashDelta; negative
+	Trading     *big.Rat // Sum of order execution CashDelta
+
+	// Unpriced counts entries without a CashDelta, such as resolution
+	// payouts, which the closing balance does not include.
+	Unpriced int
+}
+
+// LedgerBuilder turns activity history into a running cash balance and
+// per-market net position, one LedgerEntry per activity, for end-of-period
+// statements. Activities are deduplicated by ID, so overlapping pages can
+// be added safely.
+//
+// Activities carry less than the streams: a resolution's payout is not
+// included, so its entry moves the position but not the balance. Compare
+// the result with the balance stream using Discrepancy to find what the
+// history left out.
+//
+// Note: Trade has no side field. Qty is read as signed, negative when
+// shares were sold, and each trade moves cash by -Price × Qty; fees are not
+// included.
+// Doc: api-reference/portfolio/overview.mdx - GET /v1/portfolio/activities
+// Doc: api-reference/websocket/private.mdx - Ledger Entry Types
+type LedgerBuilder struct {
+	opening   *big.Rat
+	balance   *big.Rat
+	positions map[string]*big.Rat
+	entries   []LedgerEntry
+	seen      map[string]bool
+}
+
+// NewLedgerBuilder starts a ledger from an opening cash balance (nil for
+// zero) and opening positions keyed by market slug (nil for none).
+func NewLedgerBuilder(openingBalance *big.Rat, openingPositions map[string]UserPosition) (*LedgerBuilder, error) {
+	b := &LedgerBuilder{
+		opening:   new(big.Rat),
+		balance:   new(big.Rat),
+		positions: make(map[string]*big.Rat),
+		seen:      make(map[string]bool),
+	}
+	if openingBalance != nil {
+		b.opening.Set(openingBalance)
+		b.balance.Set(openingBalance)
+	}
+	for slug, p := range openingPositions {
+		net, err := p.NetPositionRat()
+		if err != nil {
+			return nil, fmt.Errorf("%s net position: %w", slug, err)
+		}
+		b.positions[slug] = net
+	}
+	return b, nil
+}
+
+// AddAll adds a page of activity history in chronological order; the
+// activities endpoint returns the newest first.
+func (b *LedgerBuilder) AddAll(activities []Activity) error {
+	type timed struct {
+		a *Activity
+		t time.Time
+	}
+	sorted := make([]timed, 0, len(activities))
+	for i := range activities {
+		a := &activities[i]
+		t, err := a.Time()
+		if err != nil {
+			return fmt.Errorf("activity %s: %w", a.ID(), err)
+		}
+		sorted = append(sorted, timed{a, t})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].t.Before(sorted[j].t) })
+
+	for _, s := range sorted {
+		if err := b.Add(s.a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add applies one activity, which should be no older than those already
+// added. Activities already added, by ID, are skipped, as are those of an
+// unknown kind.
+func (b *LedgerBuilder) Add(a *Activity) error {
+	id := a.ID()
+	if id != "" && b.seen[id] {
+		return nil
+	}
+	kind := a.Kind()
+	if kind == ActivityKindUnknown {
+		return nil
+	}
+	t, err := a.Time()
+	if err != nil {
+		return fmt.Errorf("activity %s: %w", id, err)
+	}
+
+	e := LedgerEntry{
+		Time:       t,
+		EntryType:  a.LedgerEntryType(),
+		ActivityID: id,
+		MarketSlug: a.MarketSlug(),
+	}
+	switch kind {
+	case ActivityKindTrade:
+		qty, err := a.Trade.QtyRat()
+		if err != nil {
+			return fmt.Errorf("trade %s qty: %w", id, err)
+		}
+		price, err := amountRat(a.Trade.Price)
+		if err != nil {
+			return fmt.Errorf("trade %s price: %w", id, err)
+		}
+		e.QtyDelta = qty
+		e.CashDelta = new(big.Rat).Mul(price, qty)
+		e.CashDelta.Neg(e.CashDelta)
+
+	case ActivityKindPositionResolution:
+		after, err := netPosition(a.PositionResolution.AfterPosition)
+		if err != nil {
+			return fmt.Errorf("resolution %s after position: %w", id, err)
+		}
+		// The resolved position is authoritative, whatever was held before.
+		e.QtyDelta = new(big.Rat).Sub(after, b.position(e.MarketSlug))
+
+	case ActivityKindAccountBalanceChange:
+		amount, err := amountRat(a.AccountBalanceChange.Amount)
+		if err != nil {
+			return fmt.Errorf("balance change %s amount: %w", id, err)
+		}
+		e.CashDelta = amount
+	}
+
+	if e.CashDelta != nil {
+		b.balance.Add(b.balance, e.CashDelta)
+	}
+	e.Balance = new(big.Rat).Set(b.balance)
+	if e.QtyDelta != nil {
+		pos := new(big.Rat).Add(b.position(e.MarketSlug), e.QtyDelta)
+		b.positions[e.MarketSlug] = pos
+		e.Position = new(big.Rat).Set(pos)
+	}
+	if id != "" {
+		b.seen[id] = true
+	}
+	b.entries = append(b.entries, e)
+	return nil
+}
+
+// position returns the running net position in slug, zero if none.
+func (b *LedgerBuilder) position(slug string) *big.Rat {
+	if p, ok := b.positions[slug]; ok {
+		return p
+	}
+	return new(big.Rat)
+}
+
+// Balance returns the running cash balance.
+func (b *LedgerBuilder) Balance() *big.Rat {
+	return new(big.Rat).Set(b.balance)
+}
+
+// Position returns the running net position in a market.
+func (b *LedgerBuilder) Position(slug string) *big.Rat {
+	return new(big.Rat).Set(b.position(slug))
+}
+
+// Positions returns the running net position per market, leaving out flat
+// markets.
+func (b *LedgerBuilder) Positions() map[string]*big.Rat {
+	positions := make(map[string]*big.Rat, len(b.positions))
+	for slug, p := range b.positions {
+		if p.Sign() != 0 {
+			positions[slug] = new(big.Rat).Set(p)
+		}
+	}
+	return positions
+}
+
+// Entries returns every entry in the order added.
+func (b *LedgerBuilder) Entries() []LedgerEntry {
+	return append([]LedgerEntry(nil), b.entries...)
+}
+
+// Statement summarizes the entries from from (inclusive) to to (exclusive).
+// The opening balance is the ledger's opening balance plus every earlier
+// entry.
+func (b *LedgerBuilder) Statement(from, to time.Time) LedgerStatement {
+	s := LedgerStatement{
+		From:        from,
+		To:          to,
+		Deposits:    new(big.Rat),
+		Withdrawals: new(big.Rat),
+		Trading:     new(big.Rat),
+	}
+	s.OpeningBalance = new(big.Rat).Set(b.opening)
+	for _, e := range b.entries {
+		if e.Time.Before(from) && e.CashDelta != nil {
+			s.OpeningBalance.Add(s.OpeningBalance, e.CashDelta)
+		}
+	}
+	s.ClosingBalance = new(big.Rat).Set(s.OpeningBalance)
+
+	for _, e := range b.entries {
+		if e.Time.Before(from) || !e.Time.Before(to) {
+			continue
+		}
+		s.Entries = append(s.Entries, e)
+		if e.CashDelta == nil {
+			s.Unpriced++
+			continue
+		}
+		s.ClosingBalance.Add(s.ClosingBalance, e.CashDelta)
+		switch e.EntryType {
+		case LedgerEntryTypeDeposit:
+			s.Deposits.Add(s.Deposits, e.CashDelta)
+		case LedgerEntryTypeWithdrawal:
+			s.Withdrawals.Add(s.Withdrawals, e.CashDelta)
+		case LedgerEntryTypeOrderExecution:
+			s.Trading.Add(s.Trading, e.CashDelta)
+		}
+	}
+	return s
+}
+
+// Discrepancy returns bal.CurrentBalance minus the running balance: zero
+// when the ledger agrees with a balance from GetBalances or the balance
+// stream, and otherwise the cash the history does not account for, such as
+// resolution payouts and fees.
+// Doc: api-reference/websocket/private.mdx - Account Balance Subscriptions
+func (b *LedgerBuilder) Discrepancy(bal Balance) (*big.Rat, error) {
+	current, err := ParseDecimal(strconv.FormatFloat(bal.CurrentBalance, 'f', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("current balance: %w", err)
+	}
+	return current.Sub(current, b.balance), nil
+}
@@ -0,0 +1,84 @@
+package models
+
+import "sort"
+
+// Markets is a list of markets with helpers for screening and ranking, e.g.
+// Markets(resp.Markets).FilterByMinLiquidity(1000).SortBySpread().
+// Filters return a new list; sorts reorder in place and return the receiver
+// for chaining. Sorts are stable.
+type Markets []Market
+
+// FilterByMinLiquidity keeps markets with LiquidityNum of at least minLiquidity.
+func (ms Markets) FilterByMinLiquidity(minLiquidity float64) Markets {
+	return ms.Filter(func(m *Market) bool { return m.LiquidityNum >= minLiquidity })
+}
+
+// FilterByMaxSpread keeps markets with a quoted spread no wider than
+// maxSpread. Markets without a two-sided quote are dropped.
+func (ms Markets) FilterByMaxSpread(maxSpread float64) Markets {
+	return ms.Filter(func(m *Market) bool { return hasSpread(m) && m.Spread <= maxSpread })
+}
+
+// FilterActive keeps markets that are active and not closed or archived.
+func (ms Markets) FilterActive() Markets {
+	return ms.Filter(func(m *Market) bool { return m.NotTradableReason() == "" })
+}
+
+// NotTradableReason returns why orders cannot be placed on m ("closed",
+// "archived", or "inactive"), or "" if the market is open for trading.
+func (m *Market) NotTradableReason() string {
+	switch {
+	case m.Closed:
+		return "closed"
+	case m.Archived:
+		return "archived"
+	case !m.Active:
+		return "inactive"
+	}
+	return ""
+}
+
+// Filter keeps markets for which keep returns true.
+func (ms Markets) Filter(keep func(*Market) bool) Markets {
+	var out Markets
+	for i := range ms {
+		if keep(&ms[i]) {
+			out = append(out, ms[i])
+		}
+	}
+	return out
+}
+
+// SortBySpread orders markets from tightest to widest spread. Markets
+// without a spread sort last.
+func (ms Markets) SortBySpread() Markets {
+	sort.SliceStable(ms, func(i, j int) bool {
+		a, b := &ms[i], &ms[j]
+		if hasSpread(a) != hasSpread(b) {
+			return hasSpread(a)
+		}
+		return a.Spread < b.Spread
+	})
+	return ms
+}
+
+// SortByLiquidity orders markets from most to least liquid. Markets with
+// no reported liquidity sort last.
+func (ms Markets) SortByLiquidity() Markets {
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].LiquidityNum > ms[j].LiquidityNum })
+	return ms
+}
+
+// SortByVolume24h orders markets by 24-hour volume, highest first. Markets
+// with no reported volume sort last.
+func (ms Markets) SortByVolume24h() Markets {
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].Volume24hr > ms[j].Volume24hr })
+	return ms
+}
+
+// hasSpread reports whether the market has a two-sided quote. The spread
+// field is omitted (zero) when either side is missing, so a zero spread is
+// only trusted when both best bid and best ask are set.
+func hasSpread(m *Market) bool {
+	return m.BestBid > 0 && m.BestAsk > 0 && m.Spread >= 0
+}
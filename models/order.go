@@ -0,0 +1,384 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrderOption customizes a CreateOrderRequest built by NewLimitOrder or
+// NewMarketOrder.
+type OrderOption func(*CreateOrderRequest)
+
+// NewLimitOrder builds a good-till-cancel limit order request.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders
+func NewLimitOrder(marketSlug string, intent int, price *Amount, quantity float64, opts ...OrderOption) *CreateOrderRequest {
+	req := &CreateOrderRequest{
+		MarketSlug: marketSlug,
+		Type:       OrderTypeRequestLimit,
+		Intent:     intent,
+		Price:      price,
+		Quantity:   quantity,
+		TIF:        TIFRequestGTC,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// NewMarketOrder builds an immediate-or-cancel market order request.
+// Doc: api-reference/orders/overview.mdx - POST /v1/orders
+func NewMarketOrder(marketSlug string, intent int, quantity float64, opts ...OrderOption) *CreateOrderRequest {
+	req := &CreateOrderRequest{
+		MarketSlug: marketSlug,
+		Type:       OrderTypeRequestMarket,
+		Intent:     intent,
+		Quantity:   quantity,
+		TIF:        TIFRequestIOC,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// BuyYes builds a GTC limit order buying qty Yes shares at price.
+// price is the Yes price as a decimal probability, e.g. "0.55".
+func BuyYes(marketSlug string, qty float64, price *Amount, opts ...OrderOption) (*CreateOrderRequest, error) {
+	return newOutcomeOrder(marketSlug, OrderIntentRequestBuyYes, qty, price, opts)
+}
+
+// SellYes builds a GTC limit order selling qty Yes shares at price.
+func SellYes(marketSlug string, qty float64, price *Amount, opts ...OrderOption) (*CreateOrderRequest, error) {
+	return newOutcomeOrder(marketSlug, OrderIntentRequestSellYes, qty, price, opts)
+}
+
+// BuyNo builds a GTC limit order buying qty No shares at price.
+func BuyNo(marketSlug string, qty float64, price *Amount, opts ...OrderOption) (*CreateOrderRequest, error) {
+	return newOutcomeOrder(marketSlug, OrderIntentRequestBuyNo, qty, price, opts)
+}
+
+// SellNo builds a GTC limit order selling qty No shares at price.
+func SellNo(marketSlug string, qty float64, price *Amount, opts ...OrderOption) (*CreateOrderRequest, error) {
+	return newOutcomeOrder(marketSlug, OrderIntentRequestSellNo, qty, price, opts)
+}
+
+// newOutcomeOrder builds and validates a limit order for one of the
+// Buy/Sell Yes/No helpers.
+func newOutcomeOrder(marketSlug string, intent int, qty float64, price *Amount, opts []OrderOption) (*CreateOrderRequest, error) {
+	if qty <= 0 {
+		return nil, fmt.Errorf("quantity must be positive, got %v", qty)
+	}
+	if err := ValidatePrice(price); err != nil {
+		return nil, err
+	}
+	req := NewLimitOrder(marketSlug, intent, price, qty, opts...)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ValidatePrice checks that price is a decimal probability strictly between
+// 0 and 1, the range in which prediction market shares trade. Prices are
+// never quoted in cents on the API: a value such as 55 where 0.55 was meant
+// is rejected with a hint to use PriceFromCents. The value must be a plain
+// decimal such as "0.55": forms like ".5", "+0.5", "1/2", or "5e-1" are
+// rejected rather than sent to the server as given.
+func ValidatePrice(price *Amount) error {
+	if price == nil {
+		return fmt.Errorf("invalid price: amount is nil")
+	}
+	if !isPlainDecimal(price.Value) || strings.HasPrefix(price.Value, "-") {
+		return fmt.Errorf("invalid price %q: must be a plain decimal such as 0.55", price.Value)
+	}
+	r, err := price.Rat()
+	if err != nil {
+		return fmt.Errorf("invalid price: %w", err)
+	}
+	if r.Sign() > 0 && r.Cmp(big.NewRat(1, 1)) >= 0 && r.Cmp(big.NewRat(100, 1)) < 0 {
+		suggested := new(big.Rat).Quo(r, big.NewRat(100, 1)).FloatString(decimalPlaces(price.Value) + 2)
+		return fmt.Errorf("invalid price %s: prices are decimals between 0 and 1, not cents; "+
+			"did you mean %s? (see PriceFromCents)", price.Value, suggested)
+	}
+	if r.Sign() <= 0 || r.Cmp(big.NewRat(1, 1)) >= 0 {
+		return fmt.Errorf("invalid price %s: must be between 0 and 1", price.Value)
+	}
+	return nil
+}
+
+// PriceFromCents converts a price quoted in cents (e.g. 55) to the decimal
+// Amount the API expects (e.g. "0.55").
+func PriceFromCents(cents float64, currency string) (*Amount, error) {
+	s := strconv.FormatFloat(cents, 'f', -1, 64)
+	r, err := ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	price := &Amount{
+		Value:    new(big.Rat).Quo(r, big.NewRat(100, 1)).FloatString(decimalPlaces(s) + 2),
+		Currency: currency,
+	}
+	if err := ValidatePrice(price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// PriceToCents converts a decimal price (e.g. "0.55") to cents (e.g. "55"),
+// for display on surfaces that quote in cents.
+func PriceToCents(price *Amount) (string, error) {
+	r, err := price.Rat()
+	if err != nil {
+		return "", err
+	}
+	decimals := max(decimalPlaces(price.Value)-2, 0)
+	return new(big.Rat).Mul(r, big.NewRat(100, 1)).FloatString(decimals), nil
+}
+
+// PriceFromPercent converts a probability in percent (e.g. 55 for "YES at
+// 55%") to the decimal Amount the API expects (e.g. "0.55"), rounded to the
+// nearest multiple of tickSize (e.g. "0.01", see Market.TickSize). An empty
+// tickSize keeps the percentage's own precision. The percentage must be
+// strictly between 0 and 100, and must not round to 0 or 1.
+//
+//	price, err := models.PriceFromPercent(55, market.TickSize(), "USD")
+//	req, err := models.BuyYes(slug, 10, price)
+func PriceFromPercent(percent float64, tickSize, currency string) (*Amount, error) {
+	if math.IsNaN(percent) || percent <= 0 || percent >= 100 {
+		return nil, fmt.Errorf("invalid percentage %v: must be between 0 and 100", percent)
+	}
+	s := strconv.FormatFloat(percent, 'f', -1, 64)
+	r, err := ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	r.Quo(r, big.NewRat(100, 1))
+
+	decimals := decimalPlaces(s) + 2
+	if tickSize != "" {
+		tick, err := ParseDecimal(tickSize)
+		if err != nil || tick.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid tick size %q", tickSize)
+		}
+		r = roundToTick(r, tick)
+		decimals = decimalPlaces(tickSize)
+	}
+	value := r.FloatString(decimals)
+	if r.Sign() <= 0 || r.Cmp(big.NewRat(1, 1)) >= 0 {
+		return nil, fmt.Errorf("invalid percentage %v: rounds to %s at tick size %s", percent, value, tickSize)
+	}
+	return &Amount{Value: value, Currency: currency}, nil
+}
+
+// FormatPercent renders a decimal price as a probability percentage with
+// the given number of decimal places, e.g. "0.555" as "55.5%" with one
+// decimal. A nil price renders as "N/A"; an invalid one is shown unchanged.
+func FormatPercent(price *Amount, decimals int) string {
+	if price == nil {
+		return "N/A"
+	}
+	r, err := price.Rat()
+	if err != nil {
+		return price.Value
+	}
+	return r.Mul(r, big.NewRat(100, 1)).FloatString(decimals) + "%"
+}
+
+// WithTimeInForce sets the request's time in force (TIFRequest* constant).
+func WithTimeInForce(tif int) OrderOption {
+	return func(r *CreateOrderRequest) {
+		r.TIF = tif
+	}
+}
+
+// WithPostOnly sets participate_dont_initiate, making the order post-only:
+// it may only rest on the book and add liquidity. An order that would cross
+// the spread and execute immediately as the taker is rejected instead, so it
+// never pays taker fees. Only valid on limit orders.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest.participate_dont_initiate
+func WithPostOnly() OrderOption {
+	return func(r *CreateOrderRequest) {
+		r.ParticipateDoNotInit = true
+	}
+}
+
+// WithManualOrderIndicator marks the order as manually entered or
+// automatically generated (ManualOrderIndicator* constant).
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest.manual_order_indicator
+func WithManualOrderIndicator(indicator string) OrderOption {
+	return func(r *CreateOrderRequest) {
+		r.ManualOrderIndicator = indicator
+	}
+}
+
+// Validate checks the request for mistakes the server would reject, so they
+// can be reported before any network call.
+func (r *CreateOrderRequest) Validate() error {
+	if r.MarketSlug == "" {
+		return fmt.Errorf("market_slug is required")
+	}
+	if r.Intent < OrderIntentRequestBuyYes || r.Intent > OrderIntentRequestSellNo {
+		return fmt.Errorf("invalid intent %d: expected 1-4", r.Intent)
+	}
+	if r.Type == OrderTypeRequestLimit && r.Price == nil {
+		return fmt.Errorf("limit orders require a price")
+	}
+	if r.Price != nil {
+		if err := ValidatePrice(r.Price); err != nil {
+			return err
+		}
+	}
+	if r.ParticipateDoNotInit && r.Type != OrderTypeRequestLimit {
+		return fmt.Errorf("participate_dont_initiate (post-only) is only valid on limit orders")
+	}
+	switch r.ManualOrderIndicator {
+	case "", ManualOrderIndicatorManual, ManualOrderIndicatorAutomatic:
+	default:
+		return fmt.Errorf("invalid manual_order_indicator %q: expected %s or %s",
+			r.ManualOrderIndicator, ManualOrderIndicatorManual, ManualOrderIndicatorAutomatic)
+	}
+	return nil
+}
+
+// EstimateBuyingPowerImpact returns the cash req would consume if fully
+// executed: CashOrderQty when set, otherwise price × quantity. The amount is
+// in the price's currency, or DefaultCurrency when the price has none, so
+// it can be matched to a balance with BalanceByCurrency.
+//
+// Sell intents reduce an existing position rather than spending cash, so
+// their impact is zero. Market orders sized in shares have no price to
+// estimate from and return an error; size them with CashOrderQty instead.
+// Note: fees are not included.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest
+func EstimateBuyingPowerImpact(req *CreateOrderRequest) (*Amount, error) {
+	currency := DefaultCurrency
+	if req.Price != nil && req.Price.Currency != "" {
+		currency = req.Price.Currency
+	}
+
+	switch req.Intent {
+	case OrderIntentRequestSellYes, OrderIntentRequestSellNo:
+		return &Amount{Value: "0", Currency: currency}, nil
+	}
+
+	if req.CashOrderQty != nil {
+		if _, err := req.CashOrderQty.Rat(); err != nil {
+			return nil, fmt.Errorf("invalid cash_order_qty: %w", err)
+		}
+		if req.CashOrderQty.Currency == "" {
+			return &Amount{Value: req.CashOrderQty.Value, Currency: currency}, nil
+		}
+		return req.CashOrderQty, nil
+	}
+
+	if req.Price == nil {
+		return nil, fmt.Errorf("cannot estimate notional without a price or cash_order_qty")
+	}
+	price, err := req.Price.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+	qtyStr := strconv.FormatFloat(req.Quantity, 'f', -1, 64)
+	qty, err := ParseDecimal(qtyStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity: %w", err)
+	}
+
+	notional := new(big.Rat).Mul(price, qty)
+	decimals := decimalPlaces(req.Price.Value) + decimalPlaces(qtyStr)
+	return &Amount{Value: notional.FloatString(decimals), Currency: currency}, nil
+}
+
+// IsTerminal reports whether the order can no longer trade.
+func (s OrderState) IsTerminal() bool {
+	switch s {
+	case OrderStateFilled, OrderStateCanceled, OrderStateRejected, OrderStateExpired, OrderStateReplaced:
+		return true
+	}
+	return false
+}
+
+// quantityTolerance absorbs float rounding when comparing share quantities.
+const quantityTolerance = 1e-9
+
+// Validate checks the order's quantities for consistency. A live order must
+// satisfy Quantity == CumQuantity + LeavesQuantity; a terminal order has no
+// leaves and never more filled than ordered, and a filled order is filled in
+// full. Orders reassembled from partial updates can drift from these
+// invariants, which indicates the local copy should be refreshed.
+// Schema: api-reference/oapi-schemas/orders-schema.json - Order
+func (o *Order) Validate() error {
+	if o.Quantity < 0 || o.CumQuantity < 0 || o.LeavesQuantity < 0 {
+		return fmt.Errorf("order %s has negative quantities (qty %v, cum %v, leaves %v)",
+			o.ID, o.Quantity, o.CumQuantity, o.LeavesQuantity)
+	}
+	if o.CumQuantity > o.Quantity+quantityTolerance {
+		return fmt.Errorf("order %s filled %v of %v", o.ID, o.CumQuantity, o.Quantity)
+	}
+
+	switch {
+	case o.State == OrderStateFilled:
+		if !o.IsFullyFilled() {
+			return fmt.Errorf("order %s is %s but filled %v of %v", o.ID, o.State, o.CumQuantity, o.Quantity)
+		}
+	case o.State.IsTerminal():
+		if o.LeavesQuantity > quantityTolerance {
+			return fmt.Errorf("order %s is %s but has %v leaves", o.ID, o.State, o.LeavesQuantity)
+		}
+	default:
+		if math.Abs(o.Quantity-(o.CumQuantity+o.LeavesQuantity)) > quantityTolerance {
+			return fmt.Errorf("order %s quantity %v != cum %v + leaves %v",
+				o.ID, o.Quantity, o.CumQuantity, o.LeavesQuantity)
+		}
+	}
+	return nil
+}
+
+// FilledFraction returns CumQuantity / Quantity in [0, 1], or 0 for an order
+// with no quantity.
+func (o *Order) FilledFraction() float64 {
+	if o.Quantity <= 0 {
+		return 0
+	}
+	return math.Min(o.CumQuantity/o.Quantity, 1)
+}
+
+// IsFullyFilled reports whether the whole order quantity has executed.
+func (o *Order) IsFullyFilled() bool {
+	return o.Quantity > 0 && o.CumQuantity >= o.Quantity-quantityTolerance
+}
+
+// ExpiresAt returns when a good-till-date order expires, from GoodTillTime.
+// Orders without a GoodTillTime (GTC, IOC, FOK) never expire; for them it
+// returns the zero time and no error.
+// Schema: api-reference/oapi-schemas/orders-schema.json - Order
+func (o *Order) ExpiresAt() (time.Time, error) {
+	if o.GoodTillTime == "" {
+		return time.Time{}, nil
+	}
+	t, err := o.GoodTillTimeParsed()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("order %s good till time: %w", o.ID, err)
+	}
+	return t, nil
+}
+
+// IsExpired reports whether the order is expired at now: either the server
+// reported ORDER_STATE_EXPIRED, or the order is still live and its
+// GoodTillTime has passed. An order whose GoodTillTime does not parse is not
+// considered expired.
+func (o *Order) IsExpired(now time.Time) bool {
+	if o.State == OrderStateExpired {
+		return true
+	}
+	if o.State.IsTerminal() {
+		return false
+	}
+	at, err := o.ExpiresAt()
+	return err == nil && !at.IsZero() && !now.Before(at)
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderConstructorsJSONRoundTrip(t *testing.T) {
+	price := &Amount{Value: "0.55", Currency: "USD"}
+	tests := []struct {
+		name     string
+		req      *CreateOrderRequest
+		wantJSON []string
+		noJSON   []string
+	}{
+		{
+			name:     "limit",
+			req:      NewLimitOrder("test-market", OrderIntentRequestBuyYes, price, 10),
+			wantJSON: []string{`"type":1`, `"tif":1`, `"price":{"value":"0.55","currency":"USD"}`},
+			noJSON:   []string{"participate_dont_initiate"},
+		},
+		{
+			name:     "limit post-only",
+			req:      NewLimitOrder("test-market", OrderIntentRequestBuyYes, price, 10, WithPostOnly()),
+			wantJSON: []string{`"participate_dont_initiate":true`},
+		},
+		{
+			name:     "market",
+			req:      NewMarketOrder("test-market", OrderIntentRequestSellYes, 5),
+			wantJSON: []string{`"type":2`, `"tif":3`, `"intent":2`},
+			noJSON:   []string{"price", "participate_dont_initiate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			for _, want := range tt.wantJSON {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("JSON %s missing %s", data, want)
+				}
+			}
+			for _, field := range tt.noJSON {
+				if strings.Contains(string(data), `"`+field+`"`) {
+					t.Errorf("JSON %s should omit %s", data, field)
+				}
+			}
+
+			var got CreateOrderRequest
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(&got, tt.req) {
+				t.Errorf("round trip = %+v, want %+v", got, *tt.req)
+			}
+		})
+	}
+}
+
+func TestCreateOrderRequestValidatePostOnly(t *testing.T) {
+	price := &Amount{Value: "0.55", Currency: "USD"}
+	tests := []struct {
+		name    string
+		req     *CreateOrderRequest
+		wantErr bool
+	}{
+		{"limit post-only", NewLimitOrder("test-market", OrderIntentRequestBuyYes, price, 10, WithPostOnly()), false},
+		{"market post-only", NewMarketOrder("test-market", OrderIntentRequestBuyYes, 10, WithPostOnly()), true},
+		{"limit without price", NewLimitOrder("test-market", OrderIntentRequestBuyYes, nil, 10), true},
+		{"missing market", NewLimitOrder("", OrderIntentRequestBuyYes, price, 10), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManualOrderIndicator(t *testing.T) {
+	price := &Amount{Value: "0.55", Currency: "USD"}
+	tests := []struct {
+		name      string
+		indicator string
+		wantJSON  string
+		wantErr   bool
+	}{
+		{"manual", ManualOrderIndicatorManual, `"manual_order_indicator":"` + ManualOrderIndicatorManual + `"`, false},
+		{"automatic", ManualOrderIndicatorAutomatic, `"manual_order_indicator":"` + ManualOrderIndicatorAutomatic + `"`, false},
+		{"unset", "", "", false},
+		{"invalid", "MANUAL", `"manual_order_indicator":"MANUAL"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewLimitOrder("test-market", OrderIntentRequestBuyYes, price, 10, WithManualOrderIndicator(tt.indicator))
+			data, err := json.Marshal(req)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if tt.wantJSON == "" {
+				if strings.Contains(string(data), "manual_order_indicator") {
+					t.Errorf("JSON %s should omit manual_order_indicator", data)
+				}
+			} else if !strings.Contains(string(data), tt.wantJSON) {
+				t.Errorf("JSON %s missing %s", data, tt.wantJSON)
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePrice(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"0.55", false},
+		{"0.01", false},
+		{"0.99", false},
+		{".5", true},
+		{"+0.5", true},
+		{"1/2", true},
+		{"5e-1", true},
+		{"0x1p-1", true},
+		{"-0.5", true},
+		{"0", true},
+		{"1", true},
+		{"55", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := ValidatePrice(&Amount{Value: tt.value, Currency: "USD"})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidatePrice(%q) = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+	if err := ValidatePrice(nil); err == nil {
+		t.Error("ValidatePrice(nil) succeeded, want an error")
+	}
+}
@@ -0,0 +1,304 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// OrderBook is a snapshot of one market's resting bids and offers. Levels are
+// ordered best-first, as delivered by both the REST book endpoint and the
+// market data WebSocket.
+// Doc: api-reference/websocket/markets.mdx - Order Book Depth
+type OrderBook struct {
+	MarketSlug   string       `json:"marketSlug"`
+	Bids         []PriceLevel `json:"bids"`
+	Offers       []PriceLevel `json:"offers"`
+	State        string       `json:"state,omitempty"`
+	TransactTime string       `json:"transactTime,omitempty"`
+}
+
+// GetOrderBookResponse is the response from the REST order book endpoint.
+// Note: the endpoint is not documented; see RestClient.GetOrderBook.
+type GetOrderBookResponse struct {
+	MarketData *MarketDataUpdate `json:"marketData"`
+}
+
+// NewOrderBook builds an OrderBook from a market data update, so books
+// obtained over REST and over the WebSocket share one type.
+func NewOrderBook(md *MarketDataUpdate) *OrderBook {
+	if md == nil {
+		return &OrderBook{}
+	}
+	return &OrderBook{
+		MarketSlug:   md.MarketSlug,
+		Bids:         md.Bids,
+		Offers:       md.Offers,
+		State:        md.State,
+		TransactTime: md.TransactTime,
+	}
+}
+
+// Truncate limits each side of the book to the top depth levels.
+// A depth of zero or less leaves the book unchanged.
+func (b *OrderBook) Truncate(depth int) {
+	if depth <= 0 {
+		return
+	}
+	if len(b.Bids) > depth {
+		b.Bids = b.Bids[:depth]
+	}
+	if len(b.Offers) > depth {
+		b.Offers = b.Offers[:depth]
+	}
+}
+
+// BestBid returns the highest bid level, or nil if there are no bids.
+func (b *OrderBook) BestBid() *PriceLevel {
+	if len(b.Bids) == 0 {
+		return nil
+	}
+	return &b.Bids[0]
+}
+
+// BestAsk returns the lowest offer level, or nil if there are no offers.
+func (b *OrderBook) BestAsk() *PriceLevel {
+	if len(b.Offers) == 0 {
+		return nil
+	}
+	return &b.Offers[0]
+}
+
+// topOfBook returns the best bid and ask prices, failing if either side is empty.
+func (b *OrderBook) topOfBook() (bid, ask *big.Rat, err error) {
+	bestBid, bestAsk := b.BestBid(), b.BestAsk()
+	if bestBid == nil || bestAsk == nil {
+		return nil, nil, fmt.Errorf("order book for %s has an empty side", b.MarketSlug)
+	}
+	if bid, err = bestBid.Px.Rat(); err != nil {
+		return nil, nil, fmt.Errorf("best bid: %w", err)
+	}
+	if ask, err = bestAsk.Px.Rat(); err != nil {
+		return nil, nil, fmt.Errorf("best ask: %w", err)
+	}
+	return bid, ask, nil
+}
+
+// Spread returns best ask minus best bid.
+func (b *OrderBook) Spread() (*big.Rat, error) {
+	bid, ask, err := b.topOfBook()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Sub(ask, bid), nil
+}
+
+// Mid returns the midpoint of the best bid and best ask.
+func (b *OrderBook) Mid() (*big.Rat, error) {
+	bid, ask, err := b.topOfBook()
+	if err != nil {
+		return nil, err
+	}
+	mid := new(big.Rat).Add(bid, ask)
+	return mid.Quo(mid, big.NewRat(2, 1)), nil
+}
+
+// TopOfBook is the best bid and best offer of a market. A side with no
+// levels is nil.
+type TopOfBook struct {
+	MarketSlug   string
+	Bid          *PriceLevel
+	Ask          *PriceLevel
+	TransactTime string
+}
+
+// Top returns the book's best bid and offer.
+func (b *OrderBook) Top() TopOfBook {
+	t := TopOfBook{MarketSlug: b.MarketSlug, TransactTime: b.TransactTime}
+	if bid := b.BestBid(); bid != nil {
+		level := *bid
+		t.Bid = &level
+	}
+	if ask := b.BestAsk(); ask != nil {
+		level := *ask
+		t.Ask = &level
+	}
+	return t
+}
+
+// SameLevels reports whether t and other have the same best bid and offer,
+// price and size, ignoring TransactTime. Values are compared numerically,
+// so "0.50" equals "0.5".
+func (t TopOfBook) SameLevels(other TopOfBook) bool {
+	return sameLevel(t.Bid, other.Bid) && sameLevel(t.Ask, other.Ask)
+}
+
+// sameLevel compares two optional price levels by value, falling back to
+// the raw strings when either does not parse.
+func sameLevel(a, b *PriceLevel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return sameDecimal(a.Px.ValueOr(""), b.Px.ValueOr("")) && sameDecimal(a.Qty, b.Qty)
+}
+
+// sameDecimal compares two decimal strings numerically.
+func sameDecimal(a, b string) bool {
+	x, errA := ParseDecimal(a)
+	y, errB := ParseDecimal(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return x.Cmp(y) == 0
+}
+
+// micropriceDecimals is the precision of the Amount returned by Microprice.
+const micropriceDecimals = 6
+
+// Microprice returns the size-weighted mid of the top of book,
+// (bidPx*askSize + askPx*bidSize) / (bidSize + askSize), rounded to six
+// decimal places in the bid's currency. It leans toward the side with less
+// size, where the next trade is more likely to move the price. It fails if
+// either side is empty or both top levels have no size.
+func (b *OrderBook) Microprice() (*Amount, error) {
+	bidPx, askPx, err := b.topOfBook()
+	if err != nil {
+		return nil, err
+	}
+	bidSize, err := ParseDecimal(b.BestBid().Qty)
+	if err != nil {
+		return nil, fmt.Errorf("best bid size: %w", err)
+	}
+	askSize, err := ParseDecimal(b.BestAsk().Qty)
+	if err != nil {
+		return nil, fmt.Errorf("best ask size: %w", err)
+	}
+	total := new(big.Rat).Add(bidSize, askSize)
+	if total.Sign() <= 0 {
+		return nil, fmt.Errorf("order book for %s has no size at the top of book", b.MarketSlug)
+	}
+
+	weighted := new(big.Rat).Mul(bidPx, askSize)
+	weighted.Add(weighted, new(big.Rat).Mul(askPx, bidSize))
+	return NewAmountFromRat(weighted.Quo(weighted, total), micropriceDecimals, b.BestBid().Px.Currency)
+}
+
+// LevelChange is one price level that differs between two books. Quantities
+// are zero for a level absent from that book; Delta is NewQty - OldQty.
+type LevelChange struct {
+	Price  *Amount
+	OldQty *big.Rat
+	NewQty *big.Rat
+	Delta  *big.Rat
+}
+
+// SideDiff lists the level changes on one side of the book. Added and
+// Changed follow the newer book's order, Removed the older book's.
+type SideDiff struct {
+	Added   []LevelChange // Price present only in the newer book
+	Removed []LevelChange // Price present only in the older book
+	Changed []LevelChange // Price in both, with a different quantity
+}
+
+// BookDiff is the result of OrderBook.Diff.
+type BookDiff struct {
+	Bids   SideDiff
+	Offers SideDiff
+}
+
+// Empty reports whether the two books had identical levels.
+func (d *BookDiff) Empty() bool {
+	return d.Bids.empty() && d.Offers.empty()
+}
+
+func (d *SideDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares b (the older snapshot) with other (the newer) level by level.
+// Prices and quantities are compared as exact decimals, so "0.5" and "0.50"
+// are the same level. Use it to spot large placements or cancellations
+// between two full market data updates.
+func (b *OrderBook) Diff(other *OrderBook) (*BookDiff, error) {
+	bids, err := diffLevels(b.Bids, other.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("bids: %w", err)
+	}
+	offers, err := diffLevels(b.Offers, other.Offers)
+	if err != nil {
+		return nil, fmt.Errorf("offers: %w", err)
+	}
+	return &BookDiff{Bids: bids, Offers: offers}, nil
+}
+
+// parsedLevel is a price level with exact price and quantity.
+type parsedLevel struct {
+	px  *Amount
+	qty *big.Rat
+}
+
+// diffLevels compares one side of two books.
+func diffLevels(older, newer []PriceLevel) (SideDiff, error) {
+	var d SideDiff
+	oldKeys, oldLevels, err := indexLevels(older)
+	if err != nil {
+		return d, err
+	}
+	newKeys, newLevels, err := indexLevels(newer)
+	if err != nil {
+		return d, err
+	}
+
+	for _, key := range newKeys {
+		nl := newLevels[key]
+		ol, ok := oldLevels[key]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, levelChange(nl.px, new(big.Rat), nl.qty))
+		case ol.qty.Cmp(nl.qty) != 0:
+			d.Changed = append(d.Changed, levelChange(nl.px, ol.qty, nl.qty))
+		}
+	}
+	for _, key := range oldKeys {
+		if _, ok := newLevels[key]; !ok {
+			ol := oldLevels[key]
+			d.Removed = append(d.Removed, levelChange(ol.px, ol.qty, new(big.Rat)))
+		}
+	}
+	return d, nil
+}
+
+// indexLevels keys levels by exact price, keeping their order. Quantities of
+// repeated prices are summed.
+func indexLevels(levels []PriceLevel) ([]string, map[string]parsedLevel, error) {
+	keys := make([]string, 0, len(levels))
+	index := make(map[string]parsedLevel, len(levels))
+	for _, l := range levels {
+		px, err := l.Px.Rat()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid price: %w", err)
+		}
+		qty, err := ParseDecimal(l.Qty)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid quantity at %s: %w", l.Px.Value, err)
+		}
+		key := px.RatString()
+		if existing, ok := index[key]; ok {
+			existing.qty = new(big.Rat).Add(existing.qty, qty)
+			index[key] = existing
+			continue
+		}
+		keys = append(keys, key)
+		index[key] = parsedLevel{px: l.Px, qty: qty}
+	}
+	return keys, index, nil
+}
+
+// levelChange builds a LevelChange with its delta.
+func levelChange(px *Amount, oldQty, newQty *big.Rat) LevelChange {
+	return LevelChange{
+		Price:  px,
+		OldQty: oldQty,
+		NewQty: newQty,
+		Delta:  new(big.Rat).Sub(newQty, oldQty),
+	}
+}
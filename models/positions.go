@@ -0,0 +1,181 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// PositionApply says how a position update changes a locally held position.
+type PositionApply int
+
+const (
+	// PositionApplyNone: the entry moves cash, not shares (deposits,
+	// withdrawals, commissions), so the position is unchanged.
+	PositionApplyNone PositionApply = iota
+
+	// PositionApplyReplace: AfterPosition carries the new NetPosition and
+	// replaces the held position.
+	PositionApplyReplace
+
+	// PositionApplyAdjust: AfterPosition omits NetPosition, so the held
+	// NetPosition is shifted by the shares traded between BeforePosition
+	// and AfterPosition.
+	PositionApplyAdjust
+)
+
+// String returns a lowercase label for the mode.
+func (a PositionApply) String() string {
+	switch a {
+	case PositionApplyReplace:
+		return "replace"
+	case PositionApplyAdjust:
+		return "adjust"
+	}
+	return "none"
+}
+
+// Apply reports how u should be applied to a held position, based on its
+// EntryType and on which fields AfterPosition carries. Order executions and
+// resolutions move shares; the other ledger entry types only move cash. An
+// unknown or empty EntryType is treated as moving shares when the update
+// carries an AfterPosition.
+//
+// Note: the docs do not say whether AfterPosition is always complete.
+// Updates that omit NetPosition are applied as deltas of QtyBought and
+// QtySold.
+// Doc: api-reference/websocket/private.mdx - Ledger Entry Types
+func (u *PositionUpdate) Apply() PositionApply {
+	switch u.EntryType {
+	case LedgerEntryTypeDeposit, LedgerEntryTypeWithdrawal, LedgerEntryTypeCommission:
+		return PositionApplyNone
+	}
+	switch {
+	case u.AfterPosition == nil:
+		return PositionApplyNone
+	case u.AfterPosition.NetPosition != "":
+		return PositionApplyReplace
+	case u.BeforePosition != nil:
+		return PositionApplyAdjust
+	}
+	return PositionApplyNone
+}
+
+// ApplyTo returns cur with u applied, as decided by Apply. cur may be nil
+// when no position is held yet. Fields that AfterPosition leaves empty keep
+// their value from cur, so partial updates do not erase known fields.
+func (u *PositionUpdate) ApplyTo(cur *UserPosition) (UserPosition, error) {
+	var base UserPosition
+	if cur != nil {
+		base = *cur
+	}
+
+	switch u.Apply() {
+	case PositionApplyReplace:
+		next := mergePosition(base, *u.AfterPosition)
+		if next.UpdateTime == "" {
+			next.UpdateTime = u.UpdateTime
+		}
+		return next, nil
+
+	case PositionApplyAdjust:
+		before, err := u.BeforePosition.NetTraded()
+		if err != nil {
+			return base, fmt.Errorf("before position: %w", err)
+		}
+		after, err := u.AfterPosition.NetTraded()
+		if err != nil {
+			return base, fmt.Errorf("after position: %w", err)
+		}
+		net := new(big.Rat)
+		if base.NetPosition != "" {
+			if net, err = base.NetPositionRat(); err != nil {
+				return base, fmt.Errorf("held position: %w", err)
+			}
+		}
+		net.Add(net, after.Sub(after, before))
+
+		next := mergePosition(base, *u.AfterPosition)
+		next.NetPosition = formatQty(net)
+		if next.UpdateTime == "" {
+			next.UpdateTime = u.UpdateTime
+		}
+		return next, nil
+	}
+	return base, nil
+}
+
+// mergePosition overlays the non-empty fields of update onto base.
+func mergePosition(base, update UserPosition) UserPosition {
+	if update.NetPosition != "" {
+		base.NetPosition = update.NetPosition
+	}
+	if update.QtyBought != "" {
+		base.QtyBought = update.QtyBought
+	}
+	if update.QtySold != "" {
+		base.QtySold = update.QtySold
+	}
+	if update.Cost != nil {
+		base.Cost = update.Cost
+	}
+	if update.Realized != nil {
+		base.Realized = update.Realized
+	}
+	if update.BodPosition != "" {
+		base.BodPosition = update.BodPosition
+	}
+	if update.UpdateTime != "" {
+		base.UpdateTime = update.UpdateTime
+	}
+	if update.CashValue != nil {
+		base.CashValue = update.CashValue
+	}
+	if update.QtyAvailable != "" {
+		base.QtyAvailable = update.QtyAvailable
+	}
+	if update.MarketMetadata != nil {
+		base.MarketMetadata = update.MarketMetadata
+	}
+	base.Expired = base.Expired || update.Expired
+	return base
+}
+
+// HeldMarketSlugs returns the sorted slugs of the markets in positions where
+// the net position is not zero and has not expired. Positions whose
+// NetPosition does not parse are included, so a held market is never
+// missed.
+func HeldMarketSlugs(positions map[string]UserPosition) []string {
+	var slugs []string
+	for slug, p := range positions {
+		if p.Expired {
+			continue
+		}
+		if net, err := p.NetPositionRat(); err == nil && net.Sign() == 0 {
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// HeldMarketSlugs returns the sorted slugs of the markets held on this page
+// (see HeldMarketSlugs) together with AvailablePositions.
+//
+// Note: AvailablePositions is not described in the docs. It lists markets
+// with positions, including ones not on this page, so its slugs are
+// included as held.
+// Schema: api-reference/oapi-schemas/portfolio-schema.json - GetUserPositionsResponse
+func (r *GetPositionsResponse) HeldMarketSlugs() []string {
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, slug := range append(HeldMarketSlugs(r.Positions), r.AvailablePositions...) {
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Strings(slugs)
+	return slugs
+}
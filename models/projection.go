@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarketQuote is a reduced view of Market with the fields market scanners
+// need. Decoding into it skips the rest of each market.
+type MarketQuote struct {
+	Slug           string  `json:"slug"`
+	Active         bool    `json:"active"`
+	Closed         bool    `json:"closed"`
+	BestBid        float64 `json:"bestBid,omitempty"`
+	BestAsk        float64 `json:"bestAsk,omitempty"`
+	LastTradePrice float64 `json:"lastTradePrice,omitempty"`
+}
+
+// MarketQuoteFields lists the JSON names of the MarketQuote fields, for
+// server-side field selection.
+var MarketQuoteFields = []string{"slug", "active", "closed", "bestBid", "bestAsk", "lastTradePrice"}
+
+// GetMarketQuotesResponse is GetMarketsResponse decoded as MarketQuotes.
+type GetMarketQuotesResponse struct {
+	Markets []MarketQuote `json:"markets"`
+}
+
+// Quote returns the MarketQuote view of m.
+func (m *Market) Quote() MarketQuote {
+	return MarketQuote{
+		Slug:           m.Slug,
+		Active:         m.Active,
+		Closed:         m.Closed,
+		BestBid:        m.BestBid,
+		BestAsk:        m.BestAsk,
+		LastTradePrice: m.LastTradePrice,
+	}
+}
+
+// Project returns only the named fields of m, keyed by JSON name, e.g.
+// m.Project("slug", "bestBid"). Fields that are unknown or omitted from
+// m's JSON form (zero optional values) are left out.
+func (m *Market) Project(fields ...string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode market: %w", err)
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to decode market: %w", err)
+	}
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			projected[f] = v
+		}
+	}
+	return projected, nil
+}
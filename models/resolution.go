@@ -0,0 +1,217 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Activity types.
+// Doc: api-reference/portfolio/overview.mdx - Activity Types
+const (
+	ActivityTypeTrade                = "ACTIVITY_TYPE_TRADE"
+	ActivityTypePositionResolution   = "ACTIVITY_TYPE_POSITION_RESOLUTION"
+	ActivityTypeAccountBalanceChange = "ACTIVITY_TYPE_ACCOUNT_BALANCE_CHANGE"
+)
+
+// Resolved outcomes of a binary market.
+const (
+	SettlementOutcomeYes   = "YES"
+	SettlementOutcomeNo    = "NO"
+	SettlementOutcomeSplit = "SPLIT" // Settled between 0 and 1, e.g. a void market
+)
+
+// SettlementRat returns the settlement value as an exact rational, read
+// from its shortest decimal form.
+func (s *MarketSettlement) SettlementRat() (*big.Rat, error) {
+	return ParseDecimal(strconv.FormatFloat(s.Settlement, 'f', -1, 64))
+}
+
+// ResolvedOutcome returns which outcome won: SettlementOutcomeYes for a
+// settlement of 1, SettlementOutcomeNo for 0, and SettlementOutcomeSplit for
+// anything in between. The server's Outcome is used when it is set.
+func (s *MarketSettlement) ResolvedOutcome() string {
+	if s.Outcome != "" {
+		return strings.ToUpper(s.Outcome)
+	}
+	switch s.Settlement {
+	case 1:
+		return SettlementOutcomeYes
+	case 0:
+		return SettlementOutcomeNo
+	}
+	return SettlementOutcomeSplit
+}
+
+// Payout returns what p receives at settlement, e.g. 10 YES shares in a
+// market settled at 1 receive $10: its NetPosition times the settlement
+// value. A short position pays the settlement value instead, so its payout
+// is negative. Subtract p.Cost for the profit or loss.
+// Doc: api-reference/market/overview.mdx - Settlement
+func (s *MarketSettlement) Payout(p *UserPosition) (*big.Rat, error) {
+	settlement, err := s.SettlementRat()
+	if err != nil {
+		return nil, fmt.Errorf("settlement: %w", err)
+	}
+	net, err := netPosition(p)
+	if err != nil {
+		return nil, err
+	}
+	return net.Mul(net, settlement), nil
+}
+
+// PositionResolved reports that a market resolved and the user's position in
+// it was settled. Build one with NewPositionResolved or
+// PositionResolvedFromUpdate.
+type PositionResolved struct {
+	MarketSlug string
+	Before     *UserPosition // Position before settlement
+	After      *UserPosition // Position after settlement, usually flat
+	UpdateTime string
+
+	// Settlement is the payout per share of the market's outcome, e.g. 1 for
+	// a market that resolved YES. Nil when it is not known.
+	Settlement *big.Rat
+}
+
+// NewPositionResolved builds a PositionResolved from a position resolution
+// activity. settlement may be nil.
+func NewPositionResolved(r *PositionResolution, settlement *big.Rat) *PositionResolved {
+	return &PositionResolved{
+		MarketSlug: r.MarketSlug,
+		Before:     r.BeforePosition,
+		After:      r.AfterPosition,
+		UpdateTime: r.UpdateTime,
+		Settlement: settlement,
+	}
+}
+
+// PositionResolvedFromUpdate builds a PositionResolved from a position
+// stream update, reporting false unless the update is a resolution entry.
+// settlement may be nil.
+// Doc: api-reference/websocket/private.mdx - Ledger Entry Types
+func PositionResolvedFromUpdate(u *PositionUpdate, settlement *big.Rat) (*PositionResolved, bool) {
+	if u == nil || u.EntryType != LedgerEntryTypeResolution {
+		return nil, false
+	}
+	return &PositionResolved{
+		MarketSlug: u.MarketSlug(),
+		Before:     u.BeforePosition,
+		After:      u.AfterPosition,
+		UpdateTime: u.UpdateTime,
+		Settlement: settlement,
+	}, true
+}
+
+// MarketSlug returns the slug of the market the update is for, taken from
+// the position's market metadata. Empty if neither position carries it.
+func (u *PositionUpdate) MarketSlug() string {
+	for _, p := range []*UserPosition{u.AfterPosition, u.BeforePosition} {
+		if p != nil && p.MarketMetadata != nil && p.MarketMetadata.Slug != "" {
+			return p.MarketMetadata.Slug
+		}
+	}
+	return ""
+}
+
+// SettledQty returns the number of shares settled: the net position before
+// resolution minus the net position after. Negative for a short position.
+func (r *PositionResolved) SettledQty() (*big.Rat, error) {
+	before, err := netPosition(r.Before)
+	if err != nil {
+		return nil, fmt.Errorf("before position: %w", err)
+	}
+	after, err := netPosition(r.After)
+	if err != nil {
+		return nil, fmt.Errorf("after position: %w", err)
+	}
+	return new(big.Rat).Sub(before, after), nil
+}
+
+// Payout returns the settlement value of the position: SettledQty times
+// Settlement.
+func (r *PositionResolved) Payout() (*big.Rat, error) {
+	if r.Settlement == nil {
+		return nil, fmt.Errorf("settlement for %s is not known", r.MarketSlug)
+	}
+	qty, err := r.SettledQty()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Mul(qty, r.Settlement), nil
+}
+
+// RealizedPnL returns the profit or loss realized by the resolution. When
+// both positions report Realized, it is the change in Realized as computed
+// by the server. Otherwise it is Payout minus the position's Cost before
+// resolution.
+//
+// Note: Cost is signed, so a short position's cost is the premium received
+// and the formula holds for both sides.
+func (r *PositionResolved) RealizedPnL() (*big.Rat, error) {
+	if r.Before != nil && r.After != nil && r.Before.Realized != nil && r.After.Realized != nil {
+		before, err := r.Before.Realized.Rat()
+		if err != nil {
+			return nil, fmt.Errorf("before realized: %w", err)
+		}
+		after, err := r.After.Realized.Rat()
+		if err != nil {
+			return nil, fmt.Errorf("after realized: %w", err)
+		}
+		return new(big.Rat).Sub(after, before), nil
+	}
+
+	payout, err := r.Payout()
+	if err != nil {
+		return nil, err
+	}
+	if r.Before == nil || r.Before.Cost == nil {
+		return nil, fmt.Errorf("cost of %s position is not known", r.MarketSlug)
+	}
+	cost, err := r.Before.Cost.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("cost: %w", err)
+	}
+	return new(big.Rat).Sub(payout, cost), nil
+}
+
+// Summary returns a one-line description for notifications, e.g.
+// "will-it-rain resolved at $1.00: 10 share(s) settled, won $4.50".
+// Values that cannot be computed are left out.
+func (r *PositionResolved) Summary() string {
+	s := r.MarketSlug + " resolved"
+	if r.Settlement != nil {
+		s += " at $" + r.Settlement.FloatString(2)
+	}
+	if qty, err := r.SettledQty(); err == nil {
+		s += fmt.Sprintf(": %s share(s) settled", formatQty(qty))
+	}
+	if pnl, err := r.RealizedPnL(); err == nil {
+		switch pnl.Sign() {
+		case 1:
+			s += ", won $" + pnl.FloatString(2)
+		case -1:
+			s += ", lost $" + new(big.Rat).Neg(pnl).FloatString(2)
+		default:
+			s += ", broke even"
+		}
+	}
+	return s
+}
+
+// formatQty renders a share quantity without trailing zeros.
+func formatQty(q *big.Rat) string {
+	if q.IsInt() {
+		return q.RatString()
+	}
+	return strings.TrimRight(q.FloatString(6), "0")
+}
+
+// netPosition returns p's net position, treating a nil position as flat.
+func netPosition(p *UserPosition) (*big.Rat, error) {
+	if p == nil {
+		return new(big.Rat), nil
+	}
+	return p.NetPositionRat()
+}
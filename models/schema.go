@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is one schema violation in a request body. Field is the JSON
+// path of the offending field, e.g. "request.intent".
+type FieldError struct {
+	Field   string
+	Problem string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// SchemaError lists every schema violation found in a request body.
+type SchemaError struct {
+	Schema string // Schema name, e.g. "CreateOrderRequest"
+	Fields []*FieldError
+}
+
+func (e *SchemaError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		problems[i] = f.Error()
+	}
+	return fmt.Sprintf("%s does not match schema: %s", e.Schema, strings.Join(problems, "; "))
+}
+
+// SchemaValidator is implemented by request bodies that can be checked
+// against their schema before sending. See client.WithSchemaValidation.
+//
+// Note: the oapi-schemas files are not bundled with this client. The checks
+// mirror the schemas by hand and cover structure only: required fields,
+// enum values, and value formats. Business rules such as tick sizes are left
+// to the server and to the Validate methods.
+type SchemaValidator interface {
+	ValidateSchema() error
+}
+
+// schemaCheck collects field errors for one schema.
+type schemaCheck struct {
+	prefix string
+	errs   []*FieldError
+}
+
+func (c *schemaCheck) fail(field, format string, args ...interface{}) {
+	c.errs = append(c.errs, &FieldError{Field: c.prefix + field, Problem: fmt.Sprintf(format, args...)})
+}
+
+// amount checks that a set Amount holds a decimal value.
+func (c *schemaCheck) amount(field string, a *Amount) {
+	if a == nil {
+		return
+	}
+	if a.Value == "" {
+		c.fail(field+".value", "is required")
+	} else if _, err := ParseDecimal(a.Value); err != nil {
+		c.fail(field+".value", "%q is not a decimal", a.Value)
+	}
+}
+
+// result returns the collected errors as a *SchemaError, or nil.
+func (c *schemaCheck) result(schema string) error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &SchemaError{Schema: schema, Fields: c.errs}
+}
+
+// ValidateSchema checks the request's structure against the
+// CreateOrderRequest schema, reporting every violation.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest
+func (r *CreateOrderRequest) ValidateSchema() error {
+	c := &schemaCheck{}
+	r.checkSchema(c)
+	return c.result("CreateOrderRequest")
+}
+
+func (r *CreateOrderRequest) checkSchema(c *schemaCheck) {
+	if r.MarketSlug == "" {
+		c.fail("market_slug", "is required")
+	}
+	switch r.Type {
+	case 0, OrderTypeRequestLimit, OrderTypeRequestMarket:
+	default:
+		c.fail("type", "%d is not one of 1 (LIMIT), 2 (MARKET)", r.Type)
+	}
+	if r.Intent < OrderIntentRequestBuyYes || r.Intent > OrderIntentRequestSellNo {
+		c.fail("intent", "%d is not one of 1 (BUY_YES), 2 (SELL_YES), 3 (BUY_NO), 4 (SELL_NO)", r.Intent)
+	}
+	switch r.TIF {
+	case 0, TIFRequestGTC, TIFRequestGTD, TIFRequestIOC, TIFRequestFOK:
+	default:
+		c.fail("tif", "%d is not one of 1 (GTC), 2 (GTD), 3 (IOC), 4 (FOK)", r.TIF)
+	}
+	c.amount("price", r.Price)
+	c.amount("cash_order_qty", r.CashOrderQty)
+	if r.Quantity < 0 {
+		c.fail("quantity", "must not be negative")
+	}
+	if r.Quantity == 0 && r.CashOrderQty == nil {
+		c.fail("quantity", "is required unless cash_order_qty is set")
+	}
+	if r.TIF == TIFRequestGTD && r.GoodTillTime == "" {
+		c.fail("good_till_time", "is required when tif is 2 (GTD)")
+	}
+	if r.GoodTillTime != "" {
+		if _, err := ParseTimestamp(r.GoodTillTime); err != nil {
+			c.fail("good_till_time", "%q is not a timestamp", r.GoodTillTime)
+		}
+	}
+	switch r.ManualOrderIndicator {
+	case "", ManualOrderIndicatorManual, ManualOrderIndicatorAutomatic:
+	default:
+		c.fail("manual_order_indicator", "%q is not one of %s, %s",
+			r.ManualOrderIndicator, ManualOrderIndicatorManual, ManualOrderIndicatorAutomatic)
+	}
+}
+
+// ValidateSchema checks the wrapped order against the CreateOrderRequest
+// schema; field paths are prefixed with "request.".
+// Schema: api-reference/oapi-schemas/orders-schema.json - PreviewOrderRequest
+func (r *PreviewOrderRequest) ValidateSchema() error {
+	c := &schemaCheck{}
+	if r.Request == nil {
+		c.fail("request", "is required")
+	} else {
+		c.prefix = "request."
+		r.Request.checkSchema(c)
+	}
+	return c.result("PreviewOrderRequest")
+}
+
+// ValidateSchema checks that no slug filter is empty.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CancelOpenOrdersRequest
+func (r *CancelOpenOrdersRequest) ValidateSchema() error {
+	c := &schemaCheck{}
+	for i, slug := range r.Slugs {
+		if slug == "" {
+			c.fail(fmt.Sprintf("slugs[%d]", i), "must not be empty")
+		}
+	}
+	return c.result("CancelOpenOrdersRequest")
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OrderSizeError reports an order quantity below the market's minimum size.
+type OrderSizeError struct {
+	MarketSlug string
+	Quantity   float64
+	MinSize    float64
+}
+
+func (e *OrderSizeError) Error() string {
+	return fmt.Sprintf("quantity %v is below the minimum order size %v for market %s",
+		e.Quantity, e.MinSize, e.MarketSlug)
+}
+
+// TickSize returns the market's price increment as a decimal string, e.g.
+// "0.01", or "" when the market does not report one.
+func (m *Market) TickSize() string {
+	if m == nil || m.OrderPriceMinTickSize <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(m.OrderPriceMinTickSize, 'f', -1, 64)
+}
+
+// RoundPrice rounds price to the nearest multiple of the market's tick size,
+// halves away from zero, keeping the currency. The price is returned
+// unchanged when the market reports no tick size.
+func (m *Market) RoundPrice(price *Amount) (*Amount, error) {
+	if price == nil {
+		return nil, nil
+	}
+	tickSize := m.TickSize()
+	if tickSize == "" {
+		return price, nil
+	}
+	tick, err := ParseDecimal(tickSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tick size %q: %w", tickSize, err)
+	}
+	r, err := price.Rat()
+	if err != nil {
+		return nil, err
+	}
+	return NewAmountFromRat(roundToTick(r, tick), decimalPlaces(tickSize), price.Currency)
+}
+
+// ConformOrder adjusts req to the market's order constraints before it is
+// sent: a limit price is rounded to the tick size, and a share quantity below
+// the minimum order size is rejected with an *OrderSizeError. Constraints the
+// market does not report are skipped. Cash-sized orders (CashOrderQty) are
+// not checked against the minimum, since their share count is decided by the
+// server.
+//
+// Note: rounding to the nearest tick can move a price up to half a tick in
+// either direction; round the price yourself first if that matters.
+func (m *Market) ConformOrder(req *CreateOrderRequest) error {
+	if req == nil {
+		return fmt.Errorf("order request is nil")
+	}
+	if req.Price != nil {
+		price, err := m.RoundPrice(req.Price)
+		if err != nil {
+			return fmt.Errorf("invalid price: %w", err)
+		}
+		if r, err := price.Rat(); err == nil && r.Sign() <= 0 {
+			return fmt.Errorf("price %s rounds to %s at tick size %s",
+				req.Price.Value, price.Value, m.TickSize())
+		}
+		req.Price = price
+	}
+	if m.OrderMinSize > 0 && req.CashOrderQty == nil && req.Quantity < m.OrderMinSize {
+		return &OrderSizeError{MarketSlug: req.MarketSlug, Quantity: req.Quantity, MinSize: m.OrderMinSize}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ErrNoTimestamp is returned by the *Parsed accessors when the field is empty.
+var ErrNoTimestamp = errors.New("timestamp not set")
+
+// timestampLayouts are the textual formats ParseTimestamp accepts, tried in
+// order. Layouts without a zone are read as UTC.
+var timestampLayouts = []struct {
+	name   string
+	layout string
+}{
+	{"RFC 3339", time.RFC3339Nano},
+	{"RFC 3339 with space separator", "2006-01-02 15:04:05.999999999Z07:00"},
+	{"RFC 3339 without zone", "2006-01-02T15:04:05.999999999"},
+	{"date-time without zone", "2006-01-02 15:04:05.999999999"},
+}
+
+// ParseTimestamp parses a timestamp as sent by the API. The documented form
+// is RFC 3339 in UTC with optional fractional seconds, e.g.
+// "2024-01-01T12:00:00.123456Z" (the protobuf JSON Timestamp encoding), but
+// since formats can differ per endpoint, it also accepts:
+//   - RFC 3339 with a space instead of "T", or without a zone (read as UTC)
+//   - Unix epoch numbers in seconds, milliseconds, microseconds, or
+//     nanoseconds, told apart by magnitude, with optional fractional seconds
+//
+// If no format matches, the error lists every format tried. All *Parsed
+// accessors use it.
+// Doc: api-reference/oapi-schemas/orders-schema.json - date-time fields
+func ParseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, ErrNoTimestamp
+	}
+	for _, f := range timestampLayouts {
+		if t, err := time.Parse(f.layout, s); err == nil {
+			return t, nil
+		}
+	}
+	if t, ok := parseEpoch(s); ok {
+		return t, nil
+	}
+
+	tried := make([]string, 0, len(timestampLayouts)+1)
+	for _, f := range timestampLayouts {
+		tried = append(tried, f.name)
+	}
+	tried = append(tried, "Unix epoch (s, ms, us, ns)")
+	return time.Time{}, fmt.Errorf("invalid timestamp %q: tried %s", s, strings.Join(tried, ", "))
+}
+
+// Epoch magnitude thresholds: values below each are read in that unit.
+// Seconds cover dates until year 5138, so the ranges do not overlap for
+// realistic timestamps.
+const (
+	maxEpochSeconds = 1e11
+	maxEpochMillis  = 1e14
+	maxEpochMicros  = 1e17
+)
+
+// parseEpoch parses a Unix epoch number, inferring its unit from magnitude.
+func parseEpoch(s string) (time.Time, bool) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() < 0 || strings.ContainsAny(s, "/eE") {
+		return time.Time{}, false
+	}
+	f, _ := r.Float64()
+	var nanosPerUnit int64
+	switch {
+	case f < maxEpochSeconds:
+		nanosPerUnit = int64(time.Second)
+	case f < maxEpochMillis:
+		nanosPerUnit = int64(time.Millisecond)
+	case f < maxEpochMicros:
+		nanosPerUnit = int64(time.Microsecond)
+	default:
+		nanosPerUnit = 1
+	}
+	nanos := new(big.Rat).Mul(r, new(big.Rat).SetInt64(nanosPerUnit))
+	n := new(big.Int).Quo(nanos.Num(), nanos.Denom())
+	if !n.IsInt64() {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n.Int64()).UTC(), true
+}
+
+// InsertTimeParsed returns InsertTime as a time.Time.
+func (o *Order) InsertTimeParsed() (time.Time, error) { return ParseTimestamp(o.InsertTime) }
+
+// CreateTimeParsed returns CreateTime as a time.Time.
+func (o *Order) CreateTimeParsed() (time.Time, error) { return ParseTimestamp(o.CreateTime) }
+
+// GoodTillTimeParsed returns GoodTillTime as a time.Time.
+func (o *Order) GoodTillTimeParsed() (time.Time, error) { return ParseTimestamp(o.GoodTillTime) }
+
+// TransactTimeParsed returns TransactTime as a time.Time.
+func (e *Execution) TransactTimeParsed() (time.Time, error) { return ParseTimestamp(e.TransactTime) }
+
+// LastUpdatedParsed returns LastUpdated as a time.Time.
+func (b *Balance) LastUpdatedParsed() (time.Time, error) { return ParseTimestamp(b.LastUpdated) }
+
+// CreationTimeParsed returns CreationTime as a time.Time.
+func (w *PendingWithdrawal) CreationTimeParsed() (time.Time, error) {
+	return ParseTimestamp(w.CreationTime)
+}
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (p *UserPosition) UpdateTimeParsed() (time.Time, error) { return ParseTimestamp(p.UpdateTime) }
+
+// CreateTimeParsed returns CreateTime as a time.Time.
+func (t *Trade) CreateTimeParsed() (time.Time, error) { return ParseTimestamp(t.CreateTime) }
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (t *Trade) UpdateTimeParsed() (time.Time, error) { return ParseTimestamp(t.UpdateTime) }
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (r *PositionResolution) UpdateTimeParsed() (time.Time, error) {
+	return ParseTimestamp(r.UpdateTime)
+}
+
+// CreateTimeParsed returns CreateTime as a time.Time.
+func (c *AccountBalanceChange) CreateTimeParsed() (time.Time, error) {
+	return ParseTimestamp(c.CreateTime)
+}
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (c *AccountBalanceChange) UpdateTimeParsed() (time.Time, error) {
+	return ParseTimestamp(c.UpdateTime)
+}
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (u *PositionUpdate) UpdateTimeParsed() (time.Time, error) { return ParseTimestamp(u.UpdateTime) }
+
+// UpdateTimeParsed returns UpdateTime as a time.Time.
+func (c *BalanceChange) UpdateTimeParsed() (time.Time, error) { return ParseTimestamp(c.UpdateTime) }
+
+// TransactTimeParsed returns TransactTime as a time.Time.
+func (m *MarketDataUpdate) TransactTimeParsed() (time.Time, error) {
+	return ParseTimestamp(m.TransactTime)
+}
+
+// TradeTimeParsed returns TradeTime as a time.Time.
+func (t *TradeUpdate) TradeTimeParsed() (time.Time, error) { return ParseTimestamp(t.TradeTime) }
@@ -71,6 +71,15 @@ const (
 	TIFRequestFOK = 4 // Fill Or Kill
 )
 
+// Manual order indicator values for CreateOrderRequest.ManualOrderIndicator.
+// Identifies whether an order was entered by a person or generated by an
+// automated system, for audit and regulatory reporting.
+// Schema: api-reference/oapi-schemas/orders-schema.json - CreateOrderRequest.manual_order_indicator
+const (
+	ManualOrderIndicatorManual    = "MANUAL_ORDER_INDICATOR_MANUAL"
+	ManualOrderIndicatorAutomatic = "MANUAL_ORDER_INDICATOR_AUTOMATIC"
+)
+
 // OrderState represents the current state of an order.
 // Doc: api-reference/orders/overview.mdx - Order States
 type OrderState string
@@ -137,17 +146,17 @@ type Order struct {
 // Note: API uses snake_case field names and integer enums for type/intent/tif
 type CreateOrderRequest struct {
 	MarketSlug           string  `json:"market_slug"`
-	Type                 int     `json:"type,omitempty"`     // 1=LIMIT, 2=MARKET
+	Type                 int     `json:"type,omitempty"` // 1=LIMIT, 2=MARKET
 	Price                *Amount `json:"price,omitempty"`
 	Quantity             float64 `json:"quantity,omitempty"`
-	TIF                  int     `json:"tif,omitempty"`      // 1=GTC, 2=GTD, 3=IOC, 4=FOK
+	TIF                  int     `json:"tif,omitempty"` // 1=GTC, 2=GTD, 3=IOC, 4=FOK
 	GoodTillTime         string  `json:"good_till_time,omitempty"`
-	Intent               int     `json:"intent"`             // 1=BUY_YES, 2=SELL_YES, 3=BUY_NO, 4=SELL_NO
+	Intent               int     `json:"intent"` // 1=BUY_YES, 2=SELL_YES, 3=BUY_NO, 4=SELL_NO
 	CashOrderQty         *Amount `json:"cash_order_qty,omitempty"`
-	ParticipateDoNotInit bool    `json:"participate_dont_initiate,omitempty"`
+	ParticipateDoNotInit bool    `json:"participate_dont_initiate,omitempty"` // Post-only; see WithPostOnly
 	SynchronousExecution bool    `json:"synchronous_execution,omitempty"`
 	MaxBlockTime         string  `json:"max_block_time,omitempty"`
-	ManualOrderIndicator string  `json:"manual_order_indicator,omitempty"`
+	ManualOrderIndicator string  `json:"manual_order_indicator,omitempty"` // ManualOrderIndicator* constant
 }
 
 // Execution represents an order execution.
@@ -212,21 +221,27 @@ type PreviewOrderRequest struct {
 // Doc: api-reference/oapi-schemas/orders-schema.json - PreviewOrderResponse
 type PreviewOrderResponse struct {
 	Order *Order `json:"order"`
+
+	// Set by PreviewOrder when price rounding is enabled; not part of the
+	// API response.
+	RequestedPrice *Amount `json:"-"` // The limit price as given
+	RoundedPrice   *Amount `json:"-"` // The limit price sent
+	Warning        string  `json:"-"` // Why the price changed, or why it could not be rounded
 }
 
 // Balance represents account balance information.
 // Doc: api-reference/account/overview.mdx - Balance Fields
 type Balance struct {
-	CurrentBalance    float64           `json:"currentBalance"`
-	Currency          string            `json:"currency"`
-	BuyingPower       float64           `json:"buyingPower"`
-	AssetNotional     float64           `json:"assetNotional,omitempty"`
-	AssetAvailable    float64           `json:"assetAvailable,omitempty"`
-	PendingCredit     float64           `json:"pendingCredit,omitempty"`
-	OpenOrders        float64           `json:"openOrders,omitempty"`
-	UnsettledFunds    float64           `json:"unsettledFunds,omitempty"`
-	MarginRequirement float64           `json:"marginRequirement,omitempty"`
-	LastUpdated       string            `json:"lastUpdated,omitempty"`
+	CurrentBalance     float64             `json:"currentBalance"`
+	Currency           string              `json:"currency"`
+	BuyingPower        float64             `json:"buyingPower"`
+	AssetNotional      float64             `json:"assetNotional,omitempty"`
+	AssetAvailable     float64             `json:"assetAvailable,omitempty"`
+	PendingCredit      float64             `json:"pendingCredit,omitempty"`
+	OpenOrders         float64             `json:"openOrders,omitempty"`
+	UnsettledFunds     float64             `json:"unsettledFunds,omitempty"`
+	MarginRequirement  float64             `json:"marginRequirement,omitempty"`
+	LastUpdated        string              `json:"lastUpdated,omitempty"`
 	PendingWithdrawals []PendingWithdrawal `json:"pendingWithdrawals,omitempty"`
 }
 
@@ -245,6 +260,51 @@ type GetBalancesResponse struct {
 	Balances []Balance `json:"balances"`
 }
 
+// AccountSummary is a point-in-time snapshot composed from the balances,
+// positions, and open orders endpoints. Totals only include the sources that
+// were fetched successfully; check the per-source errors before relying on them.
+type AccountSummary struct {
+	Balances   []Balance               `json:"balances,omitempty"`
+	Positions  map[string]UserPosition `json:"positions,omitempty"`
+	OpenOrders []Order                 `json:"openOrders,omitempty"`
+
+	// Computed totals. The amounts are only filled in when every balance
+	// and position value is in one currency; otherwise MultiCurrency is set,
+	// they stay zero, and ByCurrency holds the totals, since amounts in
+	// different currencies cannot be summed. A zero total is therefore only
+	// meaningful when MultiCurrency is false.
+	TotalBalance      float64 `json:"totalBalance"`      // Sum of CurrentBalance
+	BuyingPower       float64 `json:"buyingPower"`       // Sum of BuyingPower
+	OpenOrderExposure float64 `json:"openOrderExposure"` // Sum of Balance.OpenOrders
+	PositionsValue    float64 `json:"positionsValue"`    // Sum of position CashValue
+	TotalEquity       float64 `json:"totalEquity"`       // TotalBalance + PositionsValue
+	PositionCount     int     `json:"positionCount"`
+	OpenOrderCount    int     `json:"openOrderCount"`
+
+	// ByCurrency holds the totals per currency code. MultiCurrency reports
+	// that it has more than one entry, so the totals above are left zero.
+	ByCurrency    map[string]CurrencyTotals `json:"byCurrency,omitempty"`
+	MultiCurrency bool                      `json:"multiCurrency,omitempty"`
+
+	// Per-source errors; nil when the source was fetched successfully.
+	BalancesErr   error `json:"-"`
+	PositionsErr  error `json:"-"`
+	OpenOrdersErr error `json:"-"`
+}
+
+// Err returns the first per-source error, or nil if every source succeeded.
+func (s *AccountSummary) Err() error {
+	switch {
+	case s.BalancesErr != nil:
+		return s.BalancesErr
+	case s.PositionsErr != nil:
+		return s.PositionsErr
+	case s.OpenOrdersErr != nil:
+		return s.OpenOrdersErr
+	}
+	return nil
+}
+
 // UserPosition represents a trading position.
 // Doc: api-reference/portfolio/overview.mdx - Position Fields
 // Schema: api-reference/oapi-schemas/portfolio-schema.json - UserPosition
@@ -277,9 +337,9 @@ type GetPositionsResponse struct {
 // Doc: api-reference/portfolio/overview.mdx - Activity Types
 // Schema: api-reference/oapi-schemas/portfolio-schema.json - Activity
 type Activity struct {
-	Type               string               `json:"type"`
-	Trade              *Trade               `json:"trade,omitempty"`
-	PositionResolution *PositionResolution  `json:"positionResolution,omitempty"`
+	Type                 string                `json:"type"`
+	Trade                *Trade                `json:"trade,omitempty"`
+	PositionResolution   *PositionResolution   `json:"positionResolution,omitempty"`
 	AccountBalanceChange *AccountBalanceChange `json:"accountBalanceChange,omitempty"`
 }
 
@@ -337,6 +397,7 @@ type Market struct {
 	Description        string  `json:"description,omitempty"`
 	Category           string  `json:"category,omitempty"`
 	Subcategory        string  `json:"subcategory,omitempty"`
+	EventSlug          string  `json:"eventSlug,omitempty"` // Event grouping related markets (e.g. outcomes of one event)
 	Active             bool    `json:"active"`
 	Closed             bool    `json:"closed"`
 	Archived           bool    `json:"archived"`
@@ -353,12 +414,16 @@ type Market struct {
 	Volume24hr         float64 `json:"volume24hr,omitempty"`
 	Volume1wk          float64 `json:"volume1wk,omitempty"`
 	Volume1mo          float64 `json:"volume1mo,omitempty"`
+	// Order constraints; zero when the API does not report them.
+	// See Market.ConformOrder.
+	OrderPriceMinTickSize float64 `json:"orderPriceMinTickSize,omitempty"` // Price increment, e.g. 0.01
+	OrderMinSize          float64 `json:"orderMinSize,omitempty"`          // Minimum order quantity in shares
 	// Sports market fields
 	// Doc: api-reference/market/overview.mdx - Sports Market Fields
 	// Schema: api-reference/oapi-schemas/market-schema.json - Market schema
 	SportsMarketTypeV2 string   `json:"sportsMarketTypeV2,omitempty"`
 	GameID             string   `json:"gameId,omitempty"`
-	Line               *float64 `json:"line,omitempty"`       // number in schema
+	Line               *float64 `json:"line,omitempty"` // number in schema
 	PropType           string   `json:"propType,omitempty"`
 	OutcomeTeamA       *int     `json:"outcomeTeamA,omitempty"` // integer in schema
 	OutcomeTeamB       *int     `json:"outcomeTeamB,omitempty"` // integer in schema
@@ -379,7 +444,11 @@ type GetMarketResponse struct {
 // Doc: api-reference/market/overview.mdx - Settlement
 type MarketSettlement struct {
 	Slug       string  `json:"slug"`
-	Settlement float64 `json:"settlement"`
+	Settlement float64 `json:"settlement"` // Payout per YES share
+
+	// Note: not in the documented response; decoded if the server sends
+	// it. See ResolvedOutcome.
+	Outcome string `json:"outcome,omitempty"`
 }
 
 // ========== WebSocket Types ==========
@@ -391,12 +460,14 @@ type WSSubscribeRequest struct {
 }
 
 // WSSubscription defines what to subscribe to.
-// Note: API uses snake_case and integer subscription_type
+// Note: API uses snake_case and integer subscription_type. Depth is not an
+// API field: it is never sent, and the client truncates the book locally.
 type WSSubscription struct {
 	RequestID          string   `json:"request_id"`
 	SubscriptionType   int      `json:"subscription_type"`
 	MarketSlugs        []string `json:"market_slugs,omitempty"`
 	ResponsesDebounced bool     `json:"responses_debounced,omitempty"`
+	Depth              int      `json:"-"` // Market data only: top N levels per side, 0 = full book
 }
 
 // WSUnsubscribeRequest unsubscribes from a stream.
@@ -495,11 +566,11 @@ type PriceLevel struct {
 // MarketStats contains market statistics.
 // Doc: api-reference/websocket/markets.mdx - Market Data Response
 type MarketStats struct {
-	LastTradePx   *Amount `json:"lastTradePx,omitempty"`
-	SharesTraded  string  `json:"sharesTraded,omitempty"`
-	OpenInterest  string  `json:"openInterest,omitempty"`
-	HighPx        *Amount `json:"highPx,omitempty"`
-	LowPx         *Amount `json:"lowPx,omitempty"`
+	LastTradePx  *Amount `json:"lastTradePx,omitempty"`
+	SharesTraded string  `json:"sharesTraded,omitempty"`
+	OpenInterest string  `json:"openInterest,omitempty"`
+	HighPx       *Amount `json:"highPx,omitempty"`
+	LowPx        *Amount `json:"lowPx,omitempty"`
 }
 
 // MarketDataUpdate is full order book and market stats.
@@ -530,10 +601,10 @@ type MarketDataLiteUpdate struct {
 // TradeUpdate is a real-time trade notification.
 // Doc: api-reference/websocket/markets.mdx - Trade Response
 type TradeUpdate struct {
-	MarketSlug string    `json:"marketSlug"`
-	Price      *Amount   `json:"price"`
-	Quantity   *Amount   `json:"quantity"`
-	TradeTime  string    `json:"tradeTime"`
+	MarketSlug string     `json:"marketSlug"`
+	Price      *Amount    `json:"price"`
+	Quantity   *Amount    `json:"quantity"`
+	TradeTime  string     `json:"tradeTime"`
 	Maker      *TradeSide `json:"maker,omitempty"`
 	Taker      *TradeSide `json:"taker,omitempty"`
 }
@@ -558,6 +629,125 @@ const (
 	SubscriptionTypeTrade          = 3 // Trade feed
 )
 
+// SubscriptionCategory identifies the stream an inbound message belongs to.
+// The integer request constants overlap between the private and markets
+// WebSockets (1 is both ORDER and MARKET_DATA), so inbound messages are
+// classified into this connection-independent enum instead.
+type SubscriptionCategory int
+
+const (
+	SubscriptionCategoryUnknown SubscriptionCategory = iota
+	SubscriptionCategoryOrder
+	SubscriptionCategoryPosition
+	SubscriptionCategoryAccountBalance
+	SubscriptionCategoryMarketData
+	SubscriptionCategoryMarketDataLite
+	SubscriptionCategoryTrade
+)
+
+// Inbound subscriptionType string values sent by the server.
+// Doc: api-reference/websocket/overview.mdx - Subscription Types
+const (
+	SubscriptionTypeNameOrder          = "SUBSCRIPTION_TYPE_ORDER"
+	SubscriptionTypeNamePosition       = "SUBSCRIPTION_TYPE_POSITION"
+	SubscriptionTypeNameAccountBalance = "SUBSCRIPTION_TYPE_ACCOUNT_BALANCE"
+	SubscriptionTypeNameMarketData     = "SUBSCRIPTION_TYPE_MARKET_DATA"
+	SubscriptionTypeNameMarketDataLite = "SUBSCRIPTION_TYPE_MARKET_DATA_LITE"
+	SubscriptionTypeNameTrade          = "SUBSCRIPTION_TYPE_TRADE"
+)
+
+// ParseSubscriptionCategory maps an inbound subscriptionType string to its
+// category. Unrecognized values return SubscriptionCategoryUnknown.
+func ParseSubscriptionCategory(s string) SubscriptionCategory {
+	switch s {
+	case SubscriptionTypeNameOrder:
+		return SubscriptionCategoryOrder
+	case SubscriptionTypeNamePosition:
+		return SubscriptionCategoryPosition
+	case SubscriptionTypeNameAccountBalance:
+		return SubscriptionCategoryAccountBalance
+	case SubscriptionTypeNameMarketData:
+		return SubscriptionCategoryMarketData
+	case SubscriptionTypeNameMarketDataLite:
+		return SubscriptionCategoryMarketDataLite
+	case SubscriptionTypeNameTrade:
+		return SubscriptionCategoryTrade
+	}
+	return SubscriptionCategoryUnknown
+}
+
+// RequestType returns the integer subscription_type used when subscribing to
+// this category, or 0 for SubscriptionCategoryUnknown.
+func (c SubscriptionCategory) RequestType() int {
+	switch c {
+	case SubscriptionCategoryOrder:
+		return SubscriptionTypeOrder
+	case SubscriptionCategoryPosition:
+		return SubscriptionTypePosition
+	case SubscriptionCategoryAccountBalance:
+		return SubscriptionTypeAccountBalance
+	case SubscriptionCategoryMarketData:
+		return SubscriptionTypeMarketData
+	case SubscriptionCategoryMarketDataLite:
+		return SubscriptionTypeMarketDataLite
+	case SubscriptionCategoryTrade:
+		return SubscriptionTypeTrade
+	}
+	return 0
+}
+
+// IsPrivate reports whether the category is served by the private WebSocket.
+func (c SubscriptionCategory) IsPrivate() bool {
+	switch c {
+	case SubscriptionCategoryOrder, SubscriptionCategoryPosition, SubscriptionCategoryAccountBalance:
+		return true
+	}
+	return false
+}
+
+// String returns the server's name for the category.
+func (c SubscriptionCategory) String() string {
+	switch c {
+	case SubscriptionCategoryOrder:
+		return SubscriptionTypeNameOrder
+	case SubscriptionCategoryPosition:
+		return SubscriptionTypeNamePosition
+	case SubscriptionCategoryAccountBalance:
+		return SubscriptionTypeNameAccountBalance
+	case SubscriptionCategoryMarketData:
+		return SubscriptionTypeNameMarketData
+	case SubscriptionCategoryMarketDataLite:
+		return SubscriptionTypeNameMarketDataLite
+	case SubscriptionCategoryTrade:
+		return SubscriptionTypeNameTrade
+	}
+	return "SUBSCRIPTION_TYPE_UNKNOWN"
+}
+
+// Category returns the typed subscription category of the message. When the
+// server omits subscriptionType, it is inferred from the populated payload.
+func (m *WSMessage) Category() SubscriptionCategory {
+	if c := ParseSubscriptionCategory(m.SubscriptionType); c != SubscriptionCategoryUnknown {
+		return c
+	}
+
+	switch {
+	case m.OrderSubscriptionSnapshot != nil, m.OrderSubscriptionUpdate != nil:
+		return SubscriptionCategoryOrder
+	case m.PositionSubscription != nil:
+		return SubscriptionCategoryPosition
+	case m.AccountBalancesSnapshot != nil, m.AccountBalancesUpdate != nil:
+		return SubscriptionCategoryAccountBalance
+	case m.MarketData != nil:
+		return SubscriptionCategoryMarketData
+	case m.MarketDataLite != nil:
+		return SubscriptionCategoryMarketDataLite
+	case m.Trade != nil:
+		return SubscriptionCategoryTrade
+	}
+	return SubscriptionCategoryUnknown
+}
+
 // Market state constants.
 // Doc: api-reference/websocket/markets.mdx - Market States
 const (
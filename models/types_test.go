@@ -0,0 +1,23 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWSSubscriptionOmitsDepth(t *testing.T) {
+	sub := &WSSubscription{
+		RequestID:        "marketdata-1",
+		SubscriptionType: SubscriptionTypeMarketData,
+		MarketSlugs:      []string{"test-market"},
+		Depth:            5,
+	}
+	data, err := json.Marshal(&WSSubscribeRequest{Subscribe: sub})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(data), "depth") {
+		t.Errorf("subscribe request %s carries depth, which the API does not define", data)
+	}
+}